@@ -10,6 +10,7 @@ require (
 	github.com/charmbracelet/harmonica v0.2.0
 	github.com/charmbracelet/lipgloss/v2 v2.0.0-beta1
 	github.com/charmbracelet/x/ansi v0.9.3
+	github.com/charmbracelet/x/input v0.3.7
 	github.com/hashicorp/go-uuid v1.0.3
 	github.com/lucasb-eyer/go-colorful v1.2.0
 	github.com/urfave/cli-docs/v3 v3.0.0-alpha6
@@ -18,7 +19,6 @@ require (
 
 require (
 	github.com/charmbracelet/x/cellbuf v0.0.14-0.20250505150409-97991a1f17d1 // indirect
-	github.com/charmbracelet/x/input v0.3.7 // indirect
 	github.com/charmbracelet/x/term v0.2.1 // indirect
 	github.com/charmbracelet/x/windows v0.2.1 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -13,13 +14,14 @@ import (
 	"github.com/ChausseBenjamin/termpicker/internal/preview"
 	"github.com/ChausseBenjamin/termpicker/internal/switcher"
 	tea "github.com/charmbracelet/bubbletea/v2"
-	"github.com/charmbracelet/colorprofile"
 	"github.com/urfave/cli/v3"
 )
 
 //go:embed description.txt
 var Desc string
 
+var errSeverity = errors.New("cvd severity must be between 0 and 1")
+
 func AppAction(ctx context.Context, cmd *cli.Command) error {
 	logfile := logging.Setup(cmd.String(flagLogfile))
 	defer logfile.Close()
@@ -28,22 +30,78 @@ func AppAction(ctx context.Context, cmd *cli.Command) error {
 
 	sw := switcher.New(cmd.Bool(flagOneshot))
 
+	escOpts, err := escapeOpts(cmd)
+	if err != nil {
+		return err
+	}
+	sw.SetEscapeOpts(escOpts)
+
+	target, err := colors.ParseContrastTarget(cmd.String(flagCtrTarget))
+	if err != nil {
+		return err
+	}
+	sw.SetContrastTarget(target)
+
+	severity := cmd.Float(flagSeverity)
+	if severity < 0 || severity > 1 {
+		return fmt.Errorf("%w: %g", errSeverity, severity)
+	}
+	sw.SetCVDSeverity(severity)
+	sw.SetRampEasing(cmd.Bool(flagEase))
+
+	samples := cmd.Int(flagGradSamp)
+	if samples < 1 {
+		return fmt.Errorf("%w: %d", errSamples, samples)
+	}
+	sw.SetGradientSamples(int(samples))
+
+	for _, spec := range cmd.StringSlice(flagSteps) {
+		if err := setSteps(&sw, spec); err != nil {
+			return err
+		}
+	}
+
+	if path := cmd.String(flagPalette); path != "" {
+		if err := sw.OpenPalette(path); err != nil {
+			return err
+		}
+	}
+
+	if path := cmd.String(flagTokens); path != "" {
+		if err := sw.OpenTokens(path); err != nil {
+			return err
+		}
+	}
+
+	if path := cmd.String(flagScheme); path != "" {
+		if err := sw.OpenScheme(path); err != nil {
+			return err
+		}
+	}
+
 	if colorStr := cmd.String(flagColor); colorStr != "" {
 		sw.NewNotice(sw.SetColorFromText(colorStr))
 	}
 
+	if refStr := cmd.String(flagReference); refStr != "" {
+		ref, err := parse.Color(refStr)
+		if err != nil {
+			return err
+		}
+		sw.SetReference(ref)
+	}
+
 	previewStr := cmd.String(flagSampleStr)
 	fg := cmd.String(flagSampleFG)
 	bg := cmd.String(flagSampleBG)
+	// Colors left empty are asked to the terminal once the program starts
 	for _, ptr := range []*string{&fg, &bg} {
-		if *ptr != "" { // <- Hack: keeps original terminal colors if none given
+		if *ptr != "" {
 			cs, err := parse.Color(*ptr)
 			if err != nil {
-				fmt.Println("Failed to parse preview Color")
-				os.Exit(1)
+				return fmt.Errorf("preview color: %w", err)
 			}
-			val := colors.Hex(cs)
-			ptr = &val
+			*ptr = colors.Hex(cs)
 		}
 	}
 	cfg := preview.Config{
@@ -55,7 +113,8 @@ func AppAction(ctx context.Context, cmd *cli.Command) error {
 
 	p := tea.NewProgram(sw,
 		tea.WithAltScreen(),
-		tea.WithColorProfile(colorprofile.TrueColor),
+		tea.WithMouseCellMotion(),
+		tea.WithKeyReleases(), // Also reports keys being held down
 		tea.WithOutput(os.Stderr),
 	)
 	if _, err := p.Run(); err != nil {
@@ -64,6 +123,22 @@ func AppAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func escapeOpts(cmd *cli.Command) (colors.EscapeOpts, error) {
+	style, err := colors.ParseEscapeStyle(cmd.String(flagEscStyle))
+	if err != nil {
+		return colors.EscapeOpts{}, err
+	}
+	depth, err := colors.ParseColorDepth(cmd.String(flagEscDepth))
+	if err != nil {
+		return colors.EscapeOpts{}, err
+	}
+	return colors.EscapeOpts{
+		Style: style,
+		Depth: depth,
+		Reset: cmd.Bool(flagEscReset),
+	}, nil
+}
+
 func Command(version string) *cli.Command {
 	cmd := &cli.Command{
 		Name:                  "termpicker",
@@ -74,6 +149,7 @@ func Command(version string) *cli.Command {
 		Authors:               []any{"Benjamin Chausse <benjamin@chausse.xyz>"},
 		Version:               version,
 		Flags:                 AppFlags,
+		Commands:              []*cli.Command{diffCommand(), contrastCommand(), scaleCommand(), gradientCommand(), paletteCommand()},
 		EnableShellCompletion: true,
 	}
 
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errContrastArgs = errors.New("contrast expects exactly one color")
+	errUnreachable  = errors.New("contrast target can't be reached")
+)
+
+func ContrastAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.NArg() != 1 {
+		return errContrastArgs
+	}
+	text, err := parse.Color(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	bg, err := parse.Color(cmd.String(flagAgainst))
+	if err != nil {
+		return err
+	}
+	target, err := colors.ParseContrastTarget(cmd.String(flagTarget))
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+	if cmd.Bool(flagFix) {
+		// Round candidates to 8-bit RGB since that's what gets printed
+		snap := func(c colors.ColorSpace) colors.ColorSpace {
+			return colors.RGB{}.FromPrecise(c.ToPrecise())
+		}
+		fixed, ok := colors.FixContrast(text, bg, target, snap)
+		if !ok {
+			return fmt.Errorf("%w: %s against %s", errUnreachable, target, colors.Hex(bg))
+		}
+		fmt.Fprintln(w, colors.Hex(fixed))
+		return nil
+	}
+
+	ratio := colors.ContrastRatio(text, bg)
+	fmt.Fprintf(w, "%s on %s\n", colors.Hex(text), colors.Hex(bg))
+	fmt.Fprintf(w, "%-8s %.2f:1 (AA %s, AAA %s, AA-large %s)\n", "wcag", ratio,
+		passFail(ratio >= colors.WCAGNormalAA),
+		passFail(ratio >= colors.WCAGNormalAAA),
+		passFail(ratio >= colors.WCAGLargeAA),
+	)
+	fmt.Fprintf(w, "%-8s Lc %.1f\n", "apca", colors.APCAContrast(text, bg))
+	fmt.Fprintf(w, "%-8s %s %s\n", "target", target, passFail(target.Met(text, bg)))
+	return nil
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
+
+func contrastCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "contrast",
+		Usage:     "Rate (or fix) the contrast of text drawn over a background",
+		ArgsUsage: "<text color>",
+		Action:    ContrastAction,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagAgainst,
+				Aliases:  []string{"a"},
+				Usage:    "Background color the text is drawn over",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    flagTarget,
+				Aliases: []string{"t"},
+				Usage:   "Contrast to reach: AA, AAA, AA-large, a WCAG ratio (4.5) or an APCA value (Lc60)",
+				Value:   "AA",
+			},
+			&cli.BoolFlag{
+				Name:    flagFix,
+				Aliases: []string{"f"},
+				Usage:   "Print the closest color (same hue, adjusted OKLCH lightness) reaching the target",
+			},
+		},
+	}
+}
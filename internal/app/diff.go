@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/urfave/cli/v3"
+)
+
+var errDiffArgs = errors.New("diff expects exactly two colors")
+
+func DiffAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.NArg() != 2 {
+		return errDiffArgs
+	}
+	a, err := parse.Color(cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	b, err := parse.Color(cmd.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	// A single metric prints a bare number so it can be used in scripts
+	if name := cmd.String(flagMetric); name != "" {
+		metric, err := colors.ParseMetric(name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.Root().Writer, metric.FormatDistance(metric.Distance(a, b)))
+		return nil
+	}
+
+	w := cmd.Root().Writer
+	fmt.Fprintf(w, "%s -> %s\n", colors.Hex(a), colors.Hex(b))
+	for _, metric := range colors.Metrics() {
+		fmt.Fprintf(w, "%-10s %s\n", metric, metric.FormatDistance(metric.Distance(a, b)))
+	}
+	fmt.Fprintf(w, "%-10s %s\n", "verdict", colors.DescribeDeltaE2000(colors.DeltaE2000(a, b)))
+	return nil
+}
+
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Measure how different two colors look",
+		ArgsUsage: "<color> <color>",
+		Action:    DiffAction,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagMetric,
+				Aliases: []string{"m"},
+				Usage:   "Only print the distance for this metric (one of: " + strings.Join(colors.MetricNames(), ", ") + ")",
+			},
+		},
+	}
+}
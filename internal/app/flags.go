@@ -1,6 +1,11 @@
 package app
 
-import "github.com/urfave/cli/v3"
+import (
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/urfave/cli/v3"
+)
 
 const (
 	flagLogfile   = "log-file"
@@ -9,6 +14,29 @@ const (
 	flagSampleBG  = "background-sample"
 	flagSampleFG  = "foreground-sample"
 	flagOneshot   = "oneshot"
+	flagEscStyle  = "escape-style"
+	flagEscDepth  = "escape-depth"
+	flagEscReset  = "escape-reset"
+	flagReference = "reference"
+	flagMetric    = "metric"
+	flagTarget    = "target"
+	flagAgainst   = "against"
+	flagFix       = "fix"
+	flagCtrTarget = "contrast-target"
+	flagSeverity  = "cvd-severity"
+	flagFormat    = "format"
+	flagName      = "name"
+	flagEase      = "ease-chroma"
+	flagSpace     = "space"
+	flagHue       = "hue"
+	flagSamples   = "samples"
+	flagGradSamp  = "gradient-samples"
+	flagPalette   = "palette"
+	flagFrom      = "from"
+	flagTo        = "to"
+	flagTokens    = "tokens"
+	flagScheme    = "scheme"
+	flagSteps     = "steps"
 )
 
 var AppFlags []cli.Flag = []cli.Flag{
@@ -19,6 +47,11 @@ var AppFlags []cli.Flag = []cli.Flag{
 		Value:       "",
 		DefaultText: "#b7416e",
 	},
+	&cli.StringFlag{
+		Name:    flagReference,
+		Usage:   "Reference color the picked color is compared against",
+		Aliases: []string{"R"},
+	},
 	&cli.StringFlag{
 		Name:        flagLogfile,
 		Usage:       "Log file",
@@ -37,20 +70,82 @@ var AppFlags []cli.Flag = []cli.Flag{
 		Name:        flagSampleBG,
 		Usage:       "Color used in the background when previewing target color as text/foreground (requires `sample-text` to be set)",
 		Aliases:     []string{"bg"},
-		DefaultText: "#111a1f",
+		DefaultText: "the terminal's background",
 		Value:       "",
 	},
 	&cli.StringFlag{
 		Name:        flagSampleFG,
 		Aliases:     []string{"fg"},
 		Usage:       "Color used for foreground/text when previewing target color as a background (requires `sample-text` to be set)",
-		DefaultText: "#ebcb88",
+		DefaultText: "the terminal's foreground",
 		Value:       "",
 	},
+	&cli.StringFlag{
+		Name:    flagCtrTarget,
+		Usage:   "Contrast reached against the background sample when fixing it: AA, AAA, AA-large, a WCAG ratio (4.5) or an APCA value (Lc60)",
+		Sources: cli.EnvVars("TERMPICKER_CONTRAST_TARGET"),
+		Value:   "AA",
+	},
+	&cli.FloatFlag{
+		Name:    flagSeverity,
+		Usage:   "Severity of the simulated color blindness, from 0 (normal vision) to 1 (dichromacy)",
+		Sources: cli.EnvVars("TERMPICKER_CVD_SEVERITY"),
+		Value:   1,
+	},
+	&cli.BoolFlag{
+		Name:    flagEase,
+		Usage:   "Make ramp shades less colorful the further they are from the color",
+		Sources: cli.EnvVars("TERMPICKER_EASE_CHROMA"),
+		Value:   true,
+	},
+	&cli.StringFlag{
+		Name:    flagPalette,
+		Aliases: []string{"p"},
+		Usage:   "Palette file (JSON, .gpl, .ase or .txt) to work on, created if missing",
+		Sources: cli.EnvVars("TERMPICKER_PALETTE"),
+	},
+	&cli.StringFlag{
+		Name:    flagTokens,
+		Usage:   "W3C design tokens file (DTCG JSON) whose color tokens can be edited",
+		Sources: cli.EnvVars("TERMPICKER_TOKENS"),
+	},
+	&cli.StringFlag{
+		Name:    flagScheme,
+		Usage:   "base16 or base24 scheme file (YAML) to edit, created if missing",
+		Sources: cli.EnvVars("TERMPICKER_SCHEME"),
+	},
+	&cli.StringSliceFlag{
+		Name:    flagSteps,
+		Usage:   "Steps of the h/l and H/L keys for a color space or one of its components, in the units its sliders show or as a % of the slider (ex: oklch.c=0.01/0.001, rgb=10%/2, hsl.h=15/)",
+		Sources: cli.EnvVars("TERMPICKER_STEPS"),
+	},
+	&cli.IntFlag{
+		Name:    flagGradSamp,
+		Usage:   "How many colors are copied when sampling a gradient",
+		Sources: cli.EnvVars("TERMPICKER_GRADIENT_SAMPLES"),
+		Value:   8,
+	},
 	&cli.BoolFlag{
 		Name:    flagOneshot,
 		Usage:   "Print the copied color to stdout and exit",
 		Aliases: []string{"1"},
 	},
+	&cli.StringFlag{
+		Name:    flagEscStyle,
+		Usage:   "How ESC is written in copied escape codes (one of: " + strings.Join(colors.EscapeStyles(), ", ") + ")",
+		Sources: cli.EnvVars("TERMPICKER_ESCAPE_STYLE"),
+		Value:   colors.EscHex.String(),
+	},
+	&cli.StringFlag{
+		Name:    flagEscDepth,
+		Usage:   "Color format of copied escape codes, nearest palette entries are used for 256/16 (one of: " + strings.Join(colors.ColorDepths(), ", ") + ")",
+		Sources: cli.EnvVars("TERMPICKER_ESCAPE_DEPTH"),
+		Value:   colors.DepthTrueColor.String(),
+	},
+	&cli.BoolFlag{
+		Name:    flagEscReset,
+		Usage:   "Append a reset sequence (ESC[0m) to copied escape codes (your text goes between the code and the reset)",
+		Sources: cli.EnvVars("TERMPICKER_ESCAPE_RESET"),
+	},
 	cli.VersionFlag,
 }
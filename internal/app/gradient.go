@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errGradientArgs = errors.New("gradient expects at least two colors")
+	errSamples      = errors.New("gradients need at least one sample")
+)
+
+func GradientAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.NArg() < 2 {
+		return errGradientArgs
+	}
+	space, err := colors.ParseInterpolation(cmd.String(flagSpace))
+	if err != nil {
+		return err
+	}
+	hue, err := colors.ParseHueDirection(cmd.String(flagHue))
+	if err != nil {
+		return err
+	}
+
+	// Stops are spread evenly, in the order they were given
+	grad := colors.Gradient{Space: space, Hue: hue}
+	for i, arg := range cmd.Args().Slice() {
+		c, err := parse.Color(arg)
+		if err != nil {
+			return err
+		}
+		pos := float64(i) / float64(cmd.NArg()-1)
+		grad.Stops = append(grad.Stops, colors.Stop{Color: c, Pos: pos})
+	}
+
+	w := cmd.Root().Writer
+	if !cmd.IsSet(flagSamples) {
+		fmt.Fprintln(w, grad.CSS())
+		return nil
+	}
+	n := cmd.Int(flagSamples)
+	if n < 1 {
+		return fmt.Errorf("%w: %d", errSamples, n)
+	}
+	for _, c := range grad.Sample(int(n)) {
+		fmt.Fprintln(w, colors.Hex(c))
+	}
+	return nil
+}
+
+func gradientCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "gradient",
+		Usage:     "Print a CSS linear-gradient() going through colors, or colors sampled along it",
+		ArgsUsage: "<color> <color> [color...]",
+		Action:    GradientAction,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagSpace,
+				Aliases: []string{"s"},
+				Usage:   "Interpolation space (one of: " + strings.Join(colors.InterpolationNames(), ", ") + ")",
+				Value:   colors.InterpOKLCH.String(),
+			},
+			&cli.StringFlag{
+				Name:  flagHue,
+				Usage: "How OKLCH hues are interpolated (one of: " + strings.Join(colors.HueDirectionNames(), ", ") + ")",
+				Value: colors.HueShorter.String(),
+			},
+			&cli.IntFlag{
+				Name:    flagSamples,
+				Aliases: []string{"n"},
+				Usage:   "Print this many colors sampled evenly along the gradient instead of CSS",
+			},
+		},
+	}
+}
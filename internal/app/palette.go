@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/palette"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errPaletteArgs = errors.New("expected a palette file and an optional output file")
+	errExportTo    = errors.New("exporting to stdout needs a --to format")
+)
+
+// PaletteImportAction converts a palette from another application to
+// termpicker's own format.
+func PaletteImportAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.NArg() < 1 || cmd.NArg() > 2 {
+		return errPaletteArgs
+	}
+	in, out := cmd.Args().Get(0), cmd.Args().Get(1)
+	from := palette.FormatFromPath(in)
+	if cmd.IsSet(flagFrom) {
+		var err error
+		if from, err = palette.ParseFormat(cmd.String(flagFrom)); err != nil {
+			return err
+		}
+	}
+	return convertPalette(cmd, in, from, out, palette.FormatJSON)
+}
+
+// PaletteExportAction converts a termpicker palette for another application
+func PaletteExportAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.NArg() < 1 || cmd.NArg() > 2 {
+		return errPaletteArgs
+	}
+	in, out := cmd.Args().Get(0), cmd.Args().Get(1)
+	var to palette.Format
+	switch {
+	case cmd.IsSet(flagTo):
+		var err error
+		if to, err = palette.ParseFormat(cmd.String(flagTo)); err != nil {
+			return err
+		}
+	case out != "":
+		to = palette.FormatFromPath(out)
+	default:
+		return errExportTo
+	}
+	return convertPalette(cmd, in, palette.FormatJSON, out, to)
+}
+
+// convertPalette writes the palette at in to out, or stdout when out is empty
+func convertPalette(cmd *cli.Command, in string, from palette.Format, out string, to palette.Format) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	name, list, err := palette.Import(data, from)
+	if err != nil {
+		return fmt.Errorf("%s: %w", in, err)
+	}
+	if name == "" {
+		name = palette.NameFromPath(in)
+	}
+	if data, err = palette.Export(to, name, list); err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = cmd.Root().Writer.Write(data)
+		return err
+	}
+	return util.WriteFileAtomic(out, data)
+}
+
+func paletteCommand() *cli.Command {
+	formats := "one of: " + strings.Join(palette.FormatNames(), ", ")
+	return &cli.Command{
+		Name:  "palette",
+		Usage: "Convert palettes between termpicker and other applications",
+		Commands: []*cli.Command{
+			{
+				Name:      "import",
+				Usage:     "Convert a GIMP/Krita (.gpl), Adobe (.ase), Paint.NET (.txt) or design tokens (.tokens) palette to termpicker's format",
+				ArgsUsage: "<palette> [output.json]",
+				Action:    PaletteImportAction,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        flagFrom,
+						Usage:       "Format of the imported palette (" + formats + ")",
+						DefaultText: "guessed from the extension",
+					},
+				},
+			},
+			{
+				Name:      "export",
+				Usage:     "Convert a termpicker palette to a GIMP/Krita (.gpl), Adobe (.ase), Paint.NET (.txt) or design tokens (.tokens) palette",
+				ArgsUsage: "<palette.json> [output]",
+				Action:    PaletteExportAction,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        flagTo,
+						Usage:       "Format of the exported palette (" + formats + ")",
+						DefaultText: "guessed from the output's extension",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errScaleArgs   = errors.New("scale expects exactly one color")
+	errScaleFormat = errors.New("unrecognized scale format")
+)
+
+const (
+	scaleCSS      = "css"
+	scaleTailwind = "tailwind"
+	scaleJSON     = "json"
+)
+
+func ScaleAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.NArg() != 1 {
+		return errScaleArgs
+	}
+	c, err := parse.Color(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	shades := colors.Ramp(c, cmd.Bool(flagEase))
+	name := cmd.String(flagName)
+	w := cmd.Root().Writer
+
+	switch format := strings.ToLower(cmd.String(flagFormat)); format {
+	case scaleCSS:
+		writeScale(w, shades, ":root {", "}", func(s colors.Shade) string {
+			return fmt.Sprintf("  --%s-%d: %s;", name, s.Step, colors.Hex(s.Color))
+		})
+	case scaleTailwind:
+		// Meant to be pasted in the theme section of tailwind.config.js
+		writeScale(w, shades, fmt.Sprintf("colors: {\n  %q: {", name), "  },\n},", func(s colors.Shade) string {
+			return fmt.Sprintf("    %d: %q,", s.Step, colors.Hex(s.Color))
+		})
+	case scaleJSON:
+		// Written by hand since encoding/json would sort "50" after "400"
+		lines := make([]string, len(shades))
+		for i, s := range shades {
+			lines[i] = fmt.Sprintf("    %q: %q", fmt.Sprint(s.Step), colors.Hex(s.Color))
+		}
+		fmt.Fprintf(w, "{\n  %q: {\n%s\n  }\n}\n", name, strings.Join(lines, ",\n"))
+	default:
+		return fmt.Errorf("%w: %q", errScaleFormat, format)
+	}
+	return nil
+}
+
+func writeScale(w io.Writer, shades []colors.Shade, open, close string, line func(colors.Shade) string) {
+	fmt.Fprintln(w, open)
+	for _, s := range shades {
+		fmt.Fprintln(w, line(s))
+	}
+	fmt.Fprintln(w, close)
+}
+
+func scaleCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "scale",
+		Usage:     "Print the 50-950 shade ramp of a color",
+		ArgsUsage: "<color>",
+		Action:    ScaleAction,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagFormat,
+				Aliases: []string{"f"},
+				Usage:   "Output format (one of: css, tailwind, json)",
+				Value:   scaleCSS,
+			},
+			&cli.StringFlag{
+				Name:    flagName,
+				Aliases: []string{"n"},
+				Usage:   "Name of the color in the output",
+				Value:   "primary",
+			},
+			&cli.BoolFlag{
+				Name:  flagEase,
+				Usage: "Make shades less colorful the further they are from the color (disable with --ease-chroma=false)",
+				Value: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/slider"
+	"github.com/ChausseBenjamin/termpicker/internal/switcher"
+)
+
+var errSteps = errors.New("slider steps must look like space[.component]=coarse/fine")
+
+// setSteps applies a --steps value (ex: "oklch.c=0.01/0.001"). Either step
+// can be left empty to keep its default.
+func setSteps(sw *switcher.Model, spec string) error {
+	target, steps, ok := strings.Cut(spec, "=")
+	coarseStr, fineStr, ok2 := strings.Cut(steps, "/")
+	if !ok || !ok2 {
+		return fmt.Errorf("%w: %q", errSteps, spec)
+	}
+	space, component, _ := strings.Cut(strings.TrimSpace(target), ".")
+
+	var parsed [2]slider.Step
+	for i, s := range []string{coarseStr, fineStr} {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		v, pcnt, err := parse.Step(s)
+		if err != nil {
+			return fmt.Errorf("steps %q: %w", spec, err)
+		}
+		parsed[i] = slider.Step{Value: v, Pcnt: pcnt}
+	}
+	return sw.SetSteps(space, component, parsed[0], parsed[1])
+}
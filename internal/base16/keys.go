@@ -0,0 +1,43 @@
+package base16
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, down, up, put key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next slot"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev slot"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "slot below"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "slot above"),
+		),
+		put: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "set slot to picked color"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.down, k.up, k.put}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
@@ -0,0 +1,109 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/follow"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const (
+	gridCols  = 8 // Grays, accents and (for base24) extra colors by row
+	cellWidth = 4
+)
+
+// Model edits the slots of a scheme, laid out 8 per row. Like terminal
+// themes, a slot loaded in the pickers follows the picked color until
+// another one is loaded.
+type Model struct {
+	scheme  *Scheme
+	sel     int
+	editing follow.Editing // Slot following the picked color
+	picked  colors.ColorSpace
+}
+
+func New(s *Scheme) *Model {
+	return &Model{scheme: s}
+}
+
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	return m.scheme.Colors[m.sel], true
+}
+
+// Load starts editing the selected slot with the pickers
+func (m Model) Load() Model {
+	m.editing.Load(m.sel)
+	return m
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	size := len(m.scheme.Colors)
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.sel = (m.sel + 1) % size
+		case key.Matches(msg, keys.prev):
+			m.sel = (m.sel + size - 1) % size
+		case key.Matches(msg, keys.down):
+			m.sel = (m.sel + gridCols) % size
+		case key.Matches(msg, keys.up):
+			m.sel = (m.sel + size - gridCols) % size
+		case key.Matches(msg, keys.put):
+			if m.picked != nil {
+				m.scheme.Set(m.sel, m.picked)
+			}
+		}
+	case colors.ColorSpace:
+		m.picked = msg
+		if i, ok := m.editing.Pick(msg); ok {
+			m.scheme.Set(i, msg)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View(width int) string {
+	title := fmt.Sprintf("%s scheme %q", m.scheme.System(), m.scheme.Name)
+	if m.scheme.Author != "" {
+		title += " by " + m.scheme.Author
+	}
+	if m.scheme.Modified() {
+		title += " [modified]"
+	}
+
+	grid := []string{}
+	for row := 0; row*gridCols < len(m.scheme.Colors); row++ {
+		cells, cursor := "", ""
+		for col := range gridCols {
+			i := row*gridCols + col
+			cells += lg.NewStyle().Background(lg.Color(m.scheme.hex(i))).Render("   ") + " "
+			if i == m.sel {
+				cursor += ui.Style().PickerCursor.Render(" " + ui.SwatchSelRune + "  ")
+			} else {
+				cursor += strings.Repeat(" ", cellWidth)
+			}
+		}
+		grid = append(grid, cells, cursor)
+	}
+
+	label := fmt.Sprintf("%s %s %s", Slot(m.sel), m.scheme.hex(m.sel), Role(m.sel))
+	if m.editing.Is(m.sel) {
+		label += " (editing)"
+	}
+
+	return strings.Join([]string{
+		ui.Style().Readout.Render(title),
+		strings.Join(grid, "\n"),
+		lg.NewStyle().MaxWidth(width).Render(ui.Style().Readout.Render(label)),
+		m.scheme.SampleView(width),
+	}, "\n")
+}
@@ -0,0 +1,106 @@
+package base16
+
+import (
+	"fmt"
+	"strings"
+
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+// Roles of the slots, from the base16 styling guidelines
+var roles = []string{
+	"default background",
+	"lighter background (status bars, line numbers)",
+	"selection background",
+	"comments, invisibles, line highlighting",
+	"dark foreground (status bars)",
+	"default foreground, caret, operators",
+	"light foreground",
+	"light background",
+	"variables, tags, diff deleted",
+	"integers, booleans, constants",
+	"classes, search background",
+	"strings, diff inserted",
+	"support, regular expressions, escapes",
+	"functions, methods, headings",
+	"keywords, storage, diff changed",
+	"deprecated, embedded language tags",
+	"darker background",
+	"darkest background",
+	"bright red",
+	"bright yellow",
+	"bright green",
+	"bright cyan",
+	"bright blue",
+	"bright magenta",
+}
+
+// Role describes what the i-th slot is used for
+func Role(i int) string {
+	return roles[i]
+}
+
+// token is a piece of the code sample highlighted with one of the slots
+type token struct {
+	text   string
+	fg, bg int
+}
+
+// plain is a token on the default background
+func plain(text string, fg int) token { return token{text, fg, 0x00} }
+
+// sample is a small highlighted Go function. Each line gets a line number
+// in the gutter, the third one being the highlighted current line.
+var sample = [][]token{
+	{plain("// Greet says hello, ", 0x03), plain("deprecated", 0x0F)},
+	{plain("func ", 0x0E), plain("Greet", 0x0D), plain("(", 0x05), plain("name ", 0x08), plain("string", 0x0A), plain(") ", 0x05), plain("error", 0x0A), plain(" {", 0x05)},
+	{plain("    msg := ", 0x08), plain("fmt.", 0x0C), plain("Sprintf", 0x0D), plain("(", 0x05), plain(`"hi %s`, 0x0B), plain(`\n`, 0x0C), plain(`"`, 0x0B), plain(", name)", 0x05)},
+	{plain("    if ", 0x0E), plain("len", 0x0C), plain("(msg) > ", 0x05), plain("42", 0x09), plain(" && ", 0x05), token{"debug", 0x05, 0x02}, plain(" == ", 0x05), plain("false", 0x09), plain(" {", 0x05)},
+	{plain("        return ", 0x0E), plain("ErrTooLong", 0x08)},
+	{plain("    }", 0x05)},
+	{plain("    return ", 0x0E), plain("nil", 0x09)},
+	{plain("}", 0x05)},
+}
+
+const currentLine = 2 // Index of the line drawn as the cursor's line
+
+// SampleView renders the code sample with the scheme's colors, above a
+// status line.
+func (s *Scheme) SampleView(width int) string {
+	style := func(fg, bg int) lg.Style {
+		return lg.NewStyle().
+			Foreground(lg.Color(s.hex(fg))).
+			Background(lg.Color(s.hex(bg)))
+	}
+
+	lines := make([]string, 0, len(sample)+1)
+	for i, tokens := range sample {
+		bg := 0x00
+		if i == currentLine {
+			bg = 0x01
+		}
+		var b strings.Builder
+		b.WriteString(style(0x03, 0x01).Render(fmt.Sprintf("%2d ", i+1)))
+		for _, tok := range tokens {
+			tokBg := tok.bg
+			if tokBg == 0x00 {
+				tokBg = bg
+			}
+			b.WriteString(style(tok.fg, tokBg).Render(tok.text))
+		}
+		lines = append(lines, s.pad(b.String(), width, bg))
+	}
+
+	status := style(0x00, 0x0D).Render(" NORMAL ") +
+		style(0x04, 0x01).Render(" main.go ") +
+		style(0x04, 0x01).Render(fmt.Sprintf(" %s %q ", s.System(), s.Name))
+	lines = append(lines, s.pad(status, width, 0x01))
+	return strings.Join(lines, "\n")
+}
+
+// pad cuts or fills a line up to width with the bg slot
+func (s *Scheme) pad(line string, width, bg int) string {
+	line = lg.NewStyle().MaxWidth(width).Render(line)
+	fill := lg.NewStyle().Background(lg.Color(s.hex(bg)))
+	return line + fill.Render(strings.Repeat(" ", max(0, width-lg.Width(line))))
+}
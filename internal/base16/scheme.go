@@ -0,0 +1,215 @@
+package base16
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+const (
+	base16 = 16
+	base24 = 24
+)
+
+var (
+	errScheme  = errors.New("invalid base16 scheme")
+	errMissing = errors.New("missing slot")
+)
+
+// Slot returns the conventional name of the i-th color (ex: base0D)
+func Slot(i int) string {
+	return fmt.Sprintf("base%02X", i)
+}
+
+// span locates a slot's value in the file (without its quotes) and
+// remembers how the hex code was written.
+type span struct {
+	line, start, end int
+	hash, upper      bool
+}
+
+// Scheme is a base16 or base24 color scheme. Both the original format (with
+// a "scheme" key) and the newer one (with "system", "name" and a "palette"
+// section) are read. Schemes are flat YAML files which are read line by line:
+// saving only rewrites the edited hex codes so comments and layout survive.
+type Scheme struct {
+	Name     string
+	Author   string
+	Colors   []colors.ColorSpace // 16 slots for base16, 24 for base24
+	path     string
+	lines    []string
+	spans    []span
+	modified bool
+}
+
+// Parse reads a scheme file
+func Parse(data []byte) (*Scheme, error) {
+	s := &Scheme{lines: strings.Split(string(data), "\n")}
+	var system string
+	found := map[int]colors.ColorSpace{}
+	spans := map[int]span{}
+
+	for i, line := range s.lines {
+		key, val, start, end, ok := keyValue(line)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "scheme", "name":
+			s.Name = val
+		case "author":
+			s.Author = val
+		case "system":
+			system = val
+		default:
+			digits, isSlot := strings.CutPrefix(key, "base")
+			n, err := strconv.ParseUint(digits, 16, 8)
+			if !isSlot || len(digits) != 2 || err != nil || n >= base24 {
+				continue
+			}
+			slot := int(n)
+			hex := strings.TrimPrefix(val, "#")
+			c, err := parse.Color("#" + hex)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", errScheme, i+1, err)
+			}
+			found[slot] = c
+			spans[slot] = span{
+				line:  i,
+				start: start,
+				end:   end,
+				hash:  len(val) != len(hex),
+				upper: hex != strings.ToLower(hex),
+			}
+		}
+	}
+
+	size := base16
+	if system == "base24" || len(found) > base16 {
+		size = base24
+	}
+	s.Colors = make([]colors.ColorSpace, size)
+	s.spans = make([]span, size)
+	for i := range size {
+		c, ok := found[i]
+		if !ok {
+			return nil, fmt.Errorf("%w: %w %s", errScheme, errMissing, Slot(i))
+		}
+		s.Colors[i], s.spans[i] = c, spans[i]
+	}
+	return s, nil
+}
+
+// keyValue splits a "key: value" line, unquoting the value and dropping
+// trailing comments. start and end locate the value in the line.
+func keyValue(line string) (key, val string, start, end int, ok bool) {
+	k, rest, found := strings.Cut(line, ":")
+	key = strings.TrimSpace(k)
+	if !found || key == "" || strings.HasPrefix(key, "#") || strings.ContainsAny(key, " \t\"'") {
+		return "", "", 0, 0, false
+	}
+	start = len(k) + 1
+	for start < len(line) && (line[start] == ' ' || line[start] == '\t') {
+		start++
+	}
+	rest = line[start:]
+
+	if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+		closing := strings.IndexByte(rest[1:], rest[0])
+		if closing < 0 {
+			return "", "", 0, 0, false
+		}
+		return key, rest[1 : closing+1], start + 1, start + 1 + closing, true
+	}
+	if i := strings.Index(rest, " #"); i >= 0 {
+		rest = rest[:i]
+	}
+	rest = strings.TrimRight(rest, " \t\r")
+	return key, rest, start, start + len(rest), true
+}
+
+// Default creates a base16 scheme from the colors of Chris Kempson's
+// "Default Dark", written in the current scheme format.
+func Default(name string) *Scheme {
+	hexes := []string{
+		"181818", "282828", "383838", "585858", "b8b8b8", "d8d8d8", "e8e8e8", "f8f8f8",
+		"ab4642", "dc9656", "f7ca88", "a1b56c", "86c1b9", "7cafc2", "ba8baf", "a16946",
+	}
+	lines := []string{
+		`system: "base16"`,
+		fmt.Sprintf("name: %q", name),
+		`author: "termpicker"`,
+		`variant: "dark"`,
+		"palette:",
+	}
+	for i, hex := range hexes {
+		lines = append(lines, fmt.Sprintf("  %s: \"#%s\"", Slot(i), hex))
+	}
+	s, _ := Parse([]byte(strings.Join(lines, "\n") + "\n"))
+	return s
+}
+
+// Open reads the scheme at path. A missing file is created with a default
+// scheme named after it.
+func Open(path string) (*Scheme, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s := Default(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		s.path = path
+		return s, s.Save()
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	s.path = path
+	return s, nil
+}
+
+// Save writes the scheme back to the file it was opened from
+func (s *Scheme) Save() error {
+	if err := util.WriteFileAtomic(s.path, s.Bytes()); err != nil {
+		return err
+	}
+	s.modified = false
+	return nil
+}
+
+func (s *Scheme) Path() string { return s.path }
+
+func (s *Scheme) Bytes() []byte { return []byte(strings.Join(s.lines, "\n")) }
+
+func (s *Scheme) Modified() bool { return s.modified }
+
+// System is either "base16" or "base24"
+func (s *Scheme) System() string { return fmt.Sprintf("base%d", len(s.Colors)) }
+
+// Set changes the color of a slot, keeping the way its hex code was written
+func (s *Scheme) Set(i int, c colors.ColorSpace) {
+	sp := s.spans[i]
+	hex := strings.TrimPrefix(colors.Hex(c), "#")
+	if !sp.upper {
+		hex = strings.ToLower(hex)
+	}
+	if sp.hash {
+		hex = "#" + hex
+	}
+	line := s.lines[sp.line]
+	s.lines[sp.line] = line[:sp.start] + hex + line[sp.end:]
+	s.spans[i].end = sp.start + len(hex)
+	s.Colors[i] = c
+	s.modified = true
+}
+
+func (s *Scheme) hex(i int) string { return colors.Hex(s.Colors[i]) }
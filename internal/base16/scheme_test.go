@@ -0,0 +1,95 @@
+package base16
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+)
+
+// The original format: unquoted hex codes without '#' and comments
+const legacy = `scheme: "Legacy" # kept
+author: "Someone"
+base00: 181818
+base01: 282828
+base02: 383838
+base03: 585858
+base04: b8b8b8
+base05: d8d8d8
+base06: e8e8e8
+base07: f8f8f8
+base08: AB4642 # red
+base09: dc9656
+base0A: f7ca88
+base0B: a1b56c
+base0C: 86c1b9
+base0D: 7cafc2
+base0E: ba8baf
+base0F: a16946
+`
+
+func TestParseLegacy(t *testing.T) {
+	s, err := Parse([]byte(legacy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "Legacy" || s.Author != "Someone" || s.System() != "base16" {
+		t.Errorf("Expected base16 scheme \"Legacy\" by Someone, got %s %q by %q", s.System(), s.Name, s.Author)
+	}
+	if colors.Hex(s.Colors[0x08]) != "#AB4642" {
+		t.Errorf("Expected base08 to be #AB4642, got %v", s.Colors[0x08])
+	}
+
+	s.Set(0x08, colors.RGB{R: 1, G: 2, B: 3})
+	s.Set(0x0D, colors.RGB{R: 4, G: 5, B: 6})
+	want := strings.NewReplacer("base08: AB4642", "base08: 010203", "base0D: 7cafc2", "base0D: 040506").Replace(legacy)
+	if string(s.Bytes()) != want {
+		t.Errorf("Expected only the edited hex codes to change:\n%s\ngot:\n%s", want, s.Bytes())
+	}
+}
+
+func TestParseBase24(t *testing.T) {
+	lines := []string{`system: "base24"`, `name: "Big"`, "palette:"}
+	for i := range base24 {
+		lines = append(lines, "  "+Slot(i)+`: "#102030"`)
+	}
+	s, err := Parse([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.System() != "base24" || len(s.Colors) != base24 || Slot(0x17) != "base17" {
+		t.Errorf("Expected a base24 scheme, got %s with %d colors", s.System(), len(s.Colors))
+	}
+	s.Set(0x17, colors.RGB{R: 255})
+	if !strings.Contains(string(s.Bytes()), `  base17: "#ff0000"`) {
+		t.Errorf("Expected base17 to keep its quotes and '#', got:\n%s", s.Bytes())
+	}
+
+	// A base24 scheme must have all of its slots
+	if _, err := Parse([]byte(strings.Join(lines[:len(lines)-1], "\n"))); !errors.Is(err, errMissing) {
+		t.Errorf("Expected a missing slot error, got %v", err)
+	}
+}
+
+func TestOpenSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mine.yaml")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "mine" || len(s.Colors) != base16 {
+		t.Errorf("Expected a new base16 scheme named after its file, got %q", s.Name)
+	}
+	s.Set(0, colors.RGB{R: 0x12, G: 0x34, B: 0x56})
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	reread, err := Parse(data)
+	if err != nil || colors.Hex(reread.Colors[0]) != "#123456" {
+		t.Errorf("Expected the edit to be saved, got %v (%v)", reread, err)
+	}
+}
@@ -162,3 +162,25 @@ func TestToOKLCH(t *testing.T) {
 		}
 	}
 }
+
+func TestNotationFormat(t *testing.T) {
+	c := RGB{183, 65, 110}
+	tests := []struct {
+		notation Notation
+		expected string
+	}{
+		{NotationHex, "#B7416E"},
+		{NotationRGB, c.String()},
+		{NotationHSL, HSL{}.FromPrecise(c.ToPrecise()).(HSL).String()},
+		{NotationCMYK, CMYK{}.FromPrecise(c.ToPrecise()).(CMYK).String()},
+		{NotationOKLCH, OKLCH{}.FromPrecise(c.ToPrecise()).(OKLCH).String()},
+	}
+	for _, test := range tests {
+		if got := test.notation.Format(c); got != test.expected {
+			t.Errorf("Expected %v to write %s, got %s", test.notation, test.expected, got)
+		}
+		if n, err := ParseNotation(test.notation.String()); err != nil || n != test.notation {
+			t.Errorf("Expected %q to parse back, got %v (%v)", test.notation, n, err)
+		}
+	}
+}
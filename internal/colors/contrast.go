@@ -0,0 +1,189 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var errContrastTarget = errors.New("invalid contrast target")
+
+// WCAG 2.x minimum contrast ratios
+const (
+	WCAGLargeAA   = 3.0 // Large text (18pt or 14pt bold), level AA
+	WCAGNormalAA  = 4.5 // Normal text level AA, large text level AAA
+	WCAGNormalAAA = 7.0 // Normal text level AAA
+)
+
+// RelativeLuminance returns the WCAG 2.x relative luminance of a color
+// (0 for black, 1 for white).
+func RelativeLuminance(cs ColorSpace) float64 {
+	p := cs.ToPrecise()
+	return 0.2126*srgbToLinear(p.R) +
+		0.7152*srgbToLinear(p.G) +
+		0.0722*srgbToLinear(p.B)
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio between two colors,
+// ranging from 1:1 to 21:1. The order of the colors doesn't matter.
+func ContrastRatio(a, b ColorSpace) float64 {
+	la := RelativeLuminance(a)
+	lb := RelativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// APCA 0.0.98G-4g constants
+const (
+	apcaExp       = 2.4
+	apcaBlkThrs   = 0.022
+	apcaBlkClmp   = 1.414
+	apcaDeltaYMin = 0.0005
+	apcaNormBG    = 0.56
+	apcaNormTXT   = 0.57
+	apcaRevTXT    = 0.62
+	apcaRevBG     = 0.65
+	apcaScale     = 1.14
+	apcaLoOffset  = 0.027
+	apcaLoClip    = 0.1
+)
+
+// APCAContrast returns the APCA lightness contrast (Lc) of text drawn over a
+// background. Unlike WCAG 2.x, the order matters: dark text on a light
+// background gives a positive value and light text on a dark background a
+// negative one. |Lc| 75 is the minimum for body text, 60 for content text
+// and 45 for large headlines.
+func APCAContrast(text, bg ColorSpace) float64 {
+	yText := apcaLuminance(text)
+	yBg := apcaLuminance(bg)
+
+	if math.Abs(yBg-yText) < apcaDeltaYMin {
+		return 0
+	}
+
+	var lc float64
+	if yBg > yText { // Dark text on a light background
+		sapc := (math.Pow(yBg, apcaNormBG) - math.Pow(yText, apcaNormTXT)) * apcaScale
+		if sapc >= apcaLoClip {
+			lc = sapc - apcaLoOffset
+		}
+	} else { // Light text on a dark background
+		sapc := (math.Pow(yBg, apcaRevBG) - math.Pow(yText, apcaRevTXT)) * apcaScale
+		if sapc <= -apcaLoClip {
+			lc = sapc + apcaLoOffset
+		}
+	}
+	return lc * 100
+}
+
+// apcaLuminance is APCA's screen luminance estimate. It uses a simple 2.4
+// gamma instead of the piecewise sRGB curve and soft clamps near black.
+func apcaLuminance(cs ColorSpace) float64 {
+	p := cs.ToPrecise()
+	y := 0.2126729*math.Pow(p.R, apcaExp) +
+		0.7151522*math.Pow(p.G, apcaExp) +
+		0.0721750*math.Pow(p.B, apcaExp)
+	if y < apcaBlkThrs {
+		y += math.Pow(apcaBlkThrs-y, apcaBlkClmp)
+	}
+	return y
+}
+
+// ContrastTarget is the minimum contrast a text color must reach against
+// its background. Exactly one of the fields is expected to be set.
+type ContrastTarget struct {
+	WCAG float64 // Minimum WCAG 2.x ratio (ex: 4.5)
+	APCA float64 // Minimum absolute APCA Lc (ex: 60)
+}
+
+func (t ContrastTarget) String() string {
+	if t.APCA > 0 {
+		return fmt.Sprintf("APCA Lc %g", t.APCA)
+	}
+	return fmt.Sprintf("WCAG %g:1", t.WCAG)
+}
+
+// Met reports whether text drawn over bg reaches the target.
+func (t ContrastTarget) Met(text, bg ColorSpace) bool {
+	if t.APCA > 0 {
+		return math.Abs(APCAContrast(text, bg)) >= t.APCA
+	}
+	return ContrastRatio(text, bg) >= t.WCAG
+}
+
+// ParseContrastTarget understands WCAG levels ("AA", "AAA", "AA-large"),
+// bare WCAG ratios ("4.5" or "4.5:1") and APCA values ("Lc60", "apca:75").
+func ParseContrastTarget(s string) (ContrastTarget, error) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	switch s {
+	case "aa":
+		return ContrastTarget{WCAG: WCAGNormalAA}, nil
+	case "aaa":
+		return ContrastTarget{WCAG: WCAGNormalAAA}, nil
+	case "aa-large", "aalarge", "aa18":
+		return ContrastTarget{WCAG: WCAGLargeAA}, nil
+	}
+
+	for _, prefix := range []string{"apca:", "apca", "lc:", "lc"} {
+		if v, found := strings.CutPrefix(s, prefix); found {
+			lc, err := strconv.ParseFloat(v, 64)
+			if err != nil || lc <= 0 || lc > 108 {
+				return ContrastTarget{}, fmt.Errorf("%w: %q", errContrastTarget, s)
+			}
+			return ContrastTarget{APCA: lc}, nil
+		}
+	}
+
+	ratio, err := strconv.ParseFloat(strings.TrimSuffix(s, ":1"), 64)
+	if err != nil || ratio < 1 || ratio > 21 {
+		return ContrastTarget{}, fmt.Errorf("%w: %q", errContrastTarget, s)
+	}
+	return ContrastTarget{WCAG: ratio}, nil
+}
+
+// FixContrast looks for the color closest to cs that reaches the target
+// against bg. Only the OKLCH lightness is searched: the hue is kept and the
+// chroma is only lowered when the new lightness can't hold it in sRGB.
+//
+// snap lets callers round candidates the way they will end up being stored
+// (ex: 8-bit RGB) so the result still meets the target after rounding. It
+// may be nil. The boolean is false when the target can't be reached.
+func FixContrast(cs, bg ColorSpace, target ContrastTarget, snap func(ColorSpace) ColorSpace) (ColorSpace, bool) {
+	if snap == nil {
+		snap = func(c ColorSpace) ColorSpace { return c }
+	}
+	if c := snap(cs); target.Met(c, bg) {
+		return c, true
+	}
+
+	start := OKLCH{}.FromPrecise(cs.ToPrecise()).(OKLCH)
+	at := func(l float64) ColorSpace {
+		return snap(OKLCH{L: l, C: start.C, H: start.H}.MapToGamut())
+	}
+
+	var best ColorSpace
+	bestDist := math.Inf(1)
+	// Try both making the color darker and lighter, keep the smallest change
+	for _, end := range []float64{0, 1} {
+		if !target.Met(at(end), bg) {
+			continue
+		}
+		fail, pass := start.L, end
+		for range 32 {
+			mid := (fail + pass) / 2
+			if target.Met(at(mid), bg) {
+				pass = mid
+			} else {
+				fail = mid
+			}
+		}
+		if d := math.Abs(pass - start.L); d < bestDist {
+			best, bestDist = at(pass), d
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,112 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		a, b     RGB
+		expected float64
+	}{
+		{RGB{0, 0, 0}, RGB{255, 255, 255}, 21},
+		{RGB{255, 255, 255}, RGB{0, 0, 0}, 21},
+		{RGB{255, 255, 255}, RGB{255, 255, 255}, 1},
+		{RGB{118, 118, 118}, RGB{255, 255, 255}, 4.54}, // #767676 is the lightest AA gray on white
+		{RGB{255, 0, 0}, RGB{255, 255, 255}, 4.00},
+		{RGB{0, 0, 255}, RGB{255, 255, 255}, 8.59},
+	}
+	for _, test := range tests {
+		if got := ContrastRatio(test.a, test.b); math.Abs(got-test.expected) > 0.01 {
+			t.Errorf("Expected contrast(%v, %v) = %.2f, got %.2f", test.a, test.b, test.expected, got)
+		}
+	}
+}
+
+// Values from the APCA reference implementation (apca-w3 0.1.9, 0.0.98G-4g)
+func TestAPCAContrast(t *testing.T) {
+	tests := []struct {
+		text, bg RGB
+		expected float64
+	}{
+		{RGB{0x88, 0x88, 0x88}, RGB{0xff, 0xff, 0xff}, 63.056},
+		{RGB{0xff, 0xff, 0xff}, RGB{0x88, 0x88, 0x88}, -68.541},
+		{RGB{0x00, 0x00, 0x00}, RGB{0xaa, 0xaa, 0xaa}, 58.146},
+		{RGB{0xaa, 0xaa, 0xaa}, RGB{0x00, 0x00, 0x00}, -56.241},
+		{RGB{0x11, 0x22, 0x33}, RGB{0xdd, 0xee, 0xff}, 91.667},
+		{RGB{0xdd, 0xee, 0xff}, RGB{0x11, 0x22, 0x33}, -93.068},
+		{RGB{0x33, 0x33, 0x33}, RGB{0x33, 0x33, 0x33}, 0},
+	}
+	for _, test := range tests {
+		if got := APCAContrast(test.text, test.bg); math.Abs(got-test.expected) > 0.01 {
+			t.Errorf("Expected Lc(%v on %v) = %.3f, got %.3f", test.text, test.bg, test.expected, got)
+		}
+	}
+}
+
+func TestParseContrastTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ContrastTarget
+		hasError bool
+	}{
+		{"AA", ContrastTarget{WCAG: 4.5}, false},
+		{"aaa", ContrastTarget{WCAG: 7}, false},
+		{"AA-large", ContrastTarget{WCAG: 3}, false},
+		{"4.5:1", ContrastTarget{WCAG: 4.5}, false},
+		{"3", ContrastTarget{WCAG: 3}, false},
+		{"Lc 60", ContrastTarget{APCA: 60}, false},
+		{"apca:75", ContrastTarget{APCA: 75}, false},
+		{"22", ContrastTarget{}, true},
+		{"lc", ContrastTarget{}, true},
+		{"bogus", ContrastTarget{}, true},
+	}
+	for _, test := range tests {
+		got, err := ParseContrastTarget(test.input)
+		if test.hasError {
+			if err == nil {
+				t.Errorf("Expected an error for %q, got %v", test.input, got)
+			}
+			continue
+		}
+		if err != nil || got != test.expected {
+			t.Errorf("Expected %q to become %v, got %v (%v)", test.input, test.expected, got, err)
+		}
+	}
+}
+
+func TestFixContrast(t *testing.T) {
+	snap := func(c ColorSpace) ColorSpace { return RGB{}.FromPrecise(c.ToPrecise()) }
+	darkBg := RGB{0x11, 0x1a, 0x1f}
+	lightBg := RGB{0xff, 0xff, 0xff}
+	pink := RGB{0xb7, 0x41, 0x6e}
+
+	for _, target := range []ContrastTarget{{WCAG: 4.5}, {WCAG: 7}, {APCA: 60}, {APCA: 75}} {
+		for _, bg := range []RGB{darkBg, lightBg} {
+			fixed, ok := FixContrast(pink, bg, target, snap)
+			if !ok {
+				t.Errorf("Expected %v to be reachable against %v", target, bg)
+				continue
+			}
+			if !target.Met(fixed, bg) {
+				t.Errorf("Fixed color %v doesn't meet %v against %v", fixed, target, bg)
+			}
+			before := OKLCH{}.FromPrecise(pink.ToPrecise()).(OKLCH)
+			after := OKLCH{}.FromPrecise(fixed.ToPrecise()).(OKLCH)
+			if dh := math.Abs(math.Mod(before.H-after.H+540, 360) - 180); dh > 5 {
+				t.Errorf("Expected the hue to be kept, went from %.1f to %.1f", before.H, after.H)
+			}
+		}
+	}
+
+	// Colors already meeting the target are left untouched
+	if fixed, _ := FixContrast(RGB{0, 0, 0}, lightBg, ContrastTarget{WCAG: 7}, snap); fixed != (RGB{0, 0, 0}) {
+		t.Errorf("Expected black to be kept as-is, got %v", fixed)
+	}
+
+	// No color reaches 21:1 against a mid gray
+	if _, ok := FixContrast(pink, RGB{128, 128, 128}, ContrastTarget{WCAG: 21}, snap); ok {
+		t.Error("Expected an unreachable target to be reported")
+	}
+}
@@ -0,0 +1,92 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// Deficiency is a type of color vision deficiency (color blindness).
+type Deficiency int
+
+const (
+	Protan  Deficiency = iota // Missing/altered L cones (red)
+	Deutan                    // Missing/altered M cones (green)
+	Tritan                    // Missing/altered S cones (blue)
+	Achroma                   // No color perception at all
+)
+
+// Full (dichromat) name first, then the anomalous trichromat name
+var deficiencies = [][]string{
+	Protan:  {"protanopia", "protanomaly", "protan"},
+	Deutan:  {"deuteranopia", "deuteranomaly", "deutan"},
+	Tritan:  {"tritanopia", "tritanomaly", "tritan"},
+	Achroma: {"achromatopsia", "achromatomaly", "achroma"},
+}
+
+func (d Deficiency) String() string { return deficiencies[d][0] }
+
+// Deficiencies lists every simulated deficiency.
+func Deficiencies() []Deficiency {
+	return []Deficiency{Protan, Deutan, Tritan, Achroma}
+}
+
+// Machado, Oliveira & Fernandes (2009) matrices for a severity of 1. They
+// apply to linear RGB.
+var machado = map[Deficiency][3][3]float64{
+	Protan: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deutan: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritan: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// CVD simulates how a color is seen with a given deficiency.
+type CVD struct {
+	Type     Deficiency
+	Severity float64 // From 0 (normal vision) to 1 (dichromacy)
+}
+
+func (c CVD) String() string {
+	if c.Severity >= 1 {
+		return c.Type.String()
+	}
+	return fmt.Sprintf("%s %d%%", deficiencies[c.Type][1], int(math.Round(c.Severity*100)))
+}
+
+// Simulate returns cs as someone with the deficiency would perceive it.
+//
+// Partial severities are interpolated between normal vision and the full
+// deficiency in linear RGB, an approximation of Machado's per-severity
+// tables. Achromatopsia keeps the relative luminance.
+func (c CVD) Simulate(cs ColorSpace) PreciseColor {
+	p := cs.ToPrecise()
+	rgb := [3]float64{srgbToLinear(p.R), srgbToLinear(p.G), srgbToLinear(p.B)}
+	s := math.Max(0, math.Min(1, c.Severity))
+
+	var sim [3]float64
+	if c.Type == Achroma {
+		y := 0.2126*rgb[0] + 0.7152*rgb[1] + 0.0722*rgb[2]
+		sim = [3]float64{y, y, y}
+	} else {
+		m := machado[c.Type]
+		for i := range sim {
+			sim[i] = m[i][0]*rgb[0] + m[i][1]*rgb[1] + m[i][2]*rgb[2]
+		}
+	}
+
+	for i := range rgb {
+		v := (1-s)*rgb[i] + s*sim[i]
+		rgb[i] = linearToSRGB(math.Max(0, math.Min(1, v)))
+	}
+	return PreciseColor{rgb[0], rgb[1], rgb[2]}
+}
@@ -0,0 +1,51 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimulateCVD(t *testing.T) {
+	red, green := RGB{255, 0, 0}, RGB{0, 160, 0}
+	for _, d := range Deficiencies() {
+		// Normal vision leaves colors untouched
+		for _, ce := range getEquivalents() {
+			if pc := (CVD{d, 0}).Simulate(ce.pc); !pcDeltaOk(pc, ce.pc) {
+				t.Errorf("Expected %v at 0%% to keep %s, got %v", d, ce.name, pc)
+			}
+		}
+		// Neutral colors are seen the same by everyone
+		for _, gray := range []RGB{{0, 0, 0}, {128, 128, 128}, {255, 255, 255}} {
+			if pc := (CVD{d, 1}).Simulate(gray); !pcDeltaOk(pc, gray.ToPrecise()) {
+				t.Errorf("Expected %v to keep gray %v, got %v", d, gray, pc)
+			}
+		}
+	}
+
+	// Red/green confusions: both deficiencies bring the pair much closer
+	before := DeltaE2000(red, green)
+	for _, d := range []Deficiency{Protan, Deutan} {
+		cvd := CVD{d, 1}
+		if after := DeltaE2000(cvd.Simulate(red), cvd.Simulate(green)); after > before/2 {
+			t.Errorf("Expected %v to confuse red and green, ΔE went from %.1f to %.1f", d, before, after)
+		}
+	}
+
+	// Achromatopsia gives a gray of the same luminance
+	sim := (CVD{Achroma, 1}).Simulate(RGB{183, 65, 110})
+	if sim.R != sim.G || sim.G != sim.B {
+		t.Errorf("Expected a gray, got %v", sim)
+	}
+	if d := RelativeLuminance(sim) - RelativeLuminance(RGB{183, 65, 110}); math.Abs(d) > 1e-9 {
+		t.Errorf("Expected the luminance to be kept, off by %f", d)
+	}
+}
+
+func TestCVDString(t *testing.T) {
+	if s := (CVD{Deutan, 1}).String(); s != "deuteranopia" {
+		t.Errorf("Expected deuteranopia, got %q", s)
+	}
+	if s := (CVD{Protan, 0.6}).String(); s != "protanomaly 60%" {
+		t.Errorf("Expected protanomaly 60%%, got %q", s)
+	}
+}
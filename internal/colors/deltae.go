@@ -0,0 +1,202 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+var errUnknownMetric = errors.New("unrecognized color difference metric")
+
+// Metric is a formula quantifying how different two colors look.
+type Metric int
+
+const (
+	CIE76     Metric = iota // Euclidean distance in Lab
+	CIE94                   // Lab with chroma/hue weighting (graphic arts)
+	CIEDE2000               // Current CIE recommendation
+	DeltaOK                 // Euclidean distance in OKLab
+)
+
+var metrics = [][]string{
+	CIE76:     {"cie76", "76", "de76"},
+	CIE94:     {"cie94", "94", "de94"},
+	CIEDE2000: {"ciede2000", "2000", "de2000", "de00"},
+	DeltaOK:   {"ok", "deok", "oklab"},
+}
+
+func (m Metric) String() string { return metrics[m][0] }
+
+// Metrics lists every metric, in order of introduction.
+func Metrics() []Metric {
+	return []Metric{CIE76, CIE94, CIEDE2000, DeltaOK}
+}
+
+// MetricNames lists the accepted names of every metric.
+func MetricNames() []string {
+	return util.Aliases(metrics)
+}
+
+func ParseMetric(s string) (Metric, error) {
+	i, err := util.LookupAlias(metrics, s, errUnknownMetric)
+	return Metric(i), err
+}
+
+// Distance measures the difference between a and b using the metric.
+func (m Metric) Distance(a, b ColorSpace) float64 {
+	switch m {
+	case CIE76:
+		return DeltaE76(a, b)
+	case CIE94:
+		return DeltaE94(a, b)
+	case DeltaOK:
+		return DeltaEOK(a, b)
+	default:
+		return DeltaE2000(a, b)
+	}
+}
+
+// DeltaE76 is the euclidean distance between two colors in Lab. A value
+// around 2.3 corresponds to a just noticeable difference.
+func DeltaE76(a, b ColorSpace) float64 {
+	x, y := toLab(a), toLab(b)
+	return math.Sqrt(
+		(x.L-y.L)*(x.L-y.L) +
+			(x.A-y.A)*(x.A-y.A) +
+			(x.B-y.B)*(x.B-y.B),
+	)
+}
+
+// DeltaE94 uses the graphic arts weights (kL=1, K1=0.045, K2=0.015).
+// Note that the formula isn't symmetric: a is the reference color.
+func DeltaE94(a, b ColorSpace) float64 {
+	const (
+		kL, kC, kH = 1.0, 1.0, 1.0
+		k1, k2     = 0.045, 0.015
+	)
+	x, y := toLab(a), toLab(b)
+
+	c1 := math.Hypot(x.A, x.B)
+	c2 := math.Hypot(y.A, y.B)
+	dL := x.L - y.L
+	dC := c1 - c2
+	dA := x.A - y.A
+	dB := x.B - y.B
+	// dH is derived from the other deltas, rounding can push it below 0
+	dH2 := math.Max(0, dA*dA+dB*dB-dC*dC)
+
+	sL := 1.0
+	sC := 1 + k1*c1
+	sH := 1 + k2*c1
+
+	return math.Sqrt(
+		(dL/(kL*sL))*(dL/(kL*sL)) +
+			(dC/(kC*sC))*(dC/(kC*sC)) +
+			dH2/((kH*sH)*(kH*sH)),
+	)
+}
+
+// DeltaE2000 implements the CIEDE2000 formula as described by Sharma, Wu
+// and Dalal (2005) with unit weighting factors.
+func DeltaE2000(a, b ColorSpace) float64 {
+	x, y := toLab(a), toLab(b)
+	pow7 := func(v float64) float64 { return v * v * v * v * v * v * v }
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	cBar := (math.Hypot(x.A, x.B) + math.Hypot(y.A, y.B)) / 2
+	g := 0.5 * (1 - math.Sqrt(pow7(cBar)/(pow7(cBar)+pow7(25))))
+
+	a1, a2 := (1+g)*x.A, (1+g)*y.A
+	c1, c2 := math.Hypot(a1, x.B), math.Hypot(a2, y.B)
+	h1, h2 := hueAngle(a1, x.B), hueAngle(a2, y.B)
+
+	dL := y.L - x.L
+	dC := c2 - c1
+
+	var dh float64
+	switch {
+	case c1*c2 == 0:
+		dh = 0
+	case math.Abs(h2-h1) <= 180:
+		dh = h2 - h1
+	case h2-h1 > 180:
+		dh = h2 - h1 - 360
+	default:
+		dh = h2 - h1 + 360
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(rad(dh/2))
+
+	lBar := (x.L + y.L) / 2
+	cBarP := (c1 + c2) / 2
+
+	var hBar float64
+	switch {
+	case c1*c2 == 0:
+		hBar = h1 + h2
+	case math.Abs(h1-h2) <= 180:
+		hBar = (h1 + h2) / 2
+	case h1+h2 < 360:
+		hBar = (h1 + h2 + 360) / 2
+	default:
+		hBar = (h1 + h2 - 360) / 2
+	}
+
+	t := 1 -
+		0.17*math.Cos(rad(hBar-30)) +
+		0.24*math.Cos(rad(2*hBar)) +
+		0.32*math.Cos(rad(3*hBar+6)) -
+		0.20*math.Cos(rad(4*hBar-63))
+
+	dTheta := 30 * math.Exp(-((hBar-275)/25)*((hBar-275)/25))
+	rC := 2 * math.Sqrt(pow7(cBarP)/(pow7(cBarP)+pow7(25)))
+	sL := 1 + (0.015*(lBar-50)*(lBar-50))/math.Sqrt(20+(lBar-50)*(lBar-50))
+	sC := 1 + 0.045*cBarP
+	sH := 1 + 0.015*cBarP*t
+	rT := -math.Sin(rad(2*dTheta)) * rC
+
+	return math.Sqrt(
+		(dL/sL)*(dL/sL) +
+			(dC/sC)*(dC/sC) +
+			(dH/sH)*(dH/sH) +
+			rT*(dC/sC)*(dH/sH),
+	)
+}
+
+// hueAngle returns the angle of (a, b) in degrees within [0, 360)
+func hueAngle(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// DescribeDeltaE2000 puts a CIEDE2000 difference into words.
+func DescribeDeltaE2000(d float64) string {
+	switch {
+	case d < 1:
+		return "imperceptible"
+	case d < 2:
+		return "close inspection"
+	case d < 10:
+		return "noticeable"
+	case d < 50:
+		return "distinct"
+	default:
+		return "opposite"
+	}
+}
+
+// FormatDistance prints a distance with a precision suited to the metric's
+// scale (OKLab distances are roughly 100 times smaller than Lab ones).
+func (m Metric) FormatDistance(d float64) string {
+	if m == DeltaOK {
+		return fmt.Sprintf("%.4f", d)
+	}
+	return fmt.Sprintf("%.2f", d)
+}
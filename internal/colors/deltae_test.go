@@ -0,0 +1,122 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// Test data published alongside "The CIEDE2000 Color-Difference Formula:
+// Implementation Notes, Supplementary Test Data, and Mathematical
+// Observations" (Sharma, Wu & Dalal, 2005)
+var ciede2000Vectors = []struct {
+	a, b     Lab
+	expected float64
+}{
+	{Lab{50.0000, 2.6772, -79.7751}, Lab{50.0000, 0.0000, -82.7485}, 2.0425},
+	{Lab{50.0000, 3.1571, -77.2803}, Lab{50.0000, 0.0000, -82.7485}, 2.8615},
+	{Lab{50.0000, 2.8361, -74.0200}, Lab{50.0000, 0.0000, -82.7485}, 3.4412},
+	{Lab{50.0000, -1.3802, -84.2814}, Lab{50.0000, 0.0000, -82.7485}, 1.0000},
+	{Lab{50.0000, -1.1848, -84.8006}, Lab{50.0000, 0.0000, -82.7485}, 1.0000},
+	{Lab{50.0000, -0.9009, -85.5211}, Lab{50.0000, 0.0000, -82.7485}, 1.0000},
+	{Lab{50.0000, 0.0000, 0.0000}, Lab{50.0000, -1.0000, 2.0000}, 2.3669},
+	{Lab{50.0000, -1.0000, 2.0000}, Lab{50.0000, 0.0000, 0.0000}, 2.3669},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0009}, 7.1792},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0010}, 7.1792},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0011}, 7.2195},
+	{Lab{50.0000, 2.4900, -0.0010}, Lab{50.0000, -2.4900, 0.0012}, 7.2195},
+	{Lab{50.0000, -0.0010, 2.4900}, Lab{50.0000, 0.0009, -2.4900}, 4.8045},
+	{Lab{50.0000, -0.0010, 2.4900}, Lab{50.0000, 0.0010, -2.4900}, 4.8045},
+	{Lab{50.0000, -0.0010, 2.4900}, Lab{50.0000, 0.0011, -2.4900}, 4.7461},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 0.0000, -2.5000}, 4.3065},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{73.0000, 25.0000, -18.0000}, 27.1492},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{61.0000, -5.0000, 29.0000}, 22.8977},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{56.0000, -27.0000, -3.0000}, 31.9030},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{58.0000, 24.0000, 15.0000}, 19.4535},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 3.1736, 0.5854}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 3.2972, 0.0000}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 1.8634, 0.5757}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000}, Lab{50.0000, 3.2592, 0.3350}, 1.0000},
+	{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.2644},
+	{Lab{63.0109, -31.0961, -5.8663}, Lab{62.8187, -29.7946, -4.0864}, 1.2630},
+	{Lab{61.2901, 3.7196, -5.3901}, Lab{61.4292, 2.2480, -4.9620}, 1.8731},
+	{Lab{35.0831, -44.1164, 3.7933}, Lab{35.0232, -40.0716, 1.5901}, 1.8645},
+	{Lab{22.7233, 20.0904, -46.6940}, Lab{23.0331, 14.9730, -42.5619}, 2.0373},
+	{Lab{36.4612, 47.8580, 18.3852}, Lab{36.2715, 50.5065, 21.2231}, 1.4146},
+	{Lab{90.8027, -2.0831, 1.4410}, Lab{91.1528, -1.6435, 0.0447}, 1.4441},
+	{Lab{90.9257, -0.5406, -0.9208}, Lab{88.6381, -0.8985, -0.7239}, 1.5381},
+	{Lab{6.7747, -0.2908, -2.4247}, Lab{5.8714, -0.0985, -2.2286}, 0.6377},
+	{Lab{2.0776, 0.0795, -1.1350}, Lab{0.9033, -0.0636, -0.5514}, 0.9082},
+}
+
+func TestDeltaE2000(t *testing.T) {
+	for i, v := range ciede2000Vectors {
+		if got := DeltaE2000(v.a, v.b); math.Abs(got-v.expected) > 1e-4 {
+			t.Errorf("Pair %d: expected ΔE2000(%v, %v) = %.4f, got %.4f", i+1, v.a, v.b, v.expected, got)
+		}
+		// CIEDE2000 is symmetric
+		if got := DeltaE2000(v.b, v.a); math.Abs(got-v.expected) > 1e-4 {
+			t.Errorf("Pair %d (swapped): expected %.4f, got %.4f", i+1, v.expected, got)
+		}
+	}
+}
+
+func TestDeltaE76And94(t *testing.T) {
+	tests := []struct {
+		a, b     Lab
+		de76     float64
+		de94     float64
+		tolerate float64
+	}{
+		{Lab{50, 0, 0}, Lab{60, 0, 0}, 10, 10, 1e-9},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 36.8680, 34.6892, 1e-4},
+		{Lab{50, 2.5, 0}, Lab{61, -5, 29}, 31.9100, 29.4414, 1e-4},
+		{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 3.1819, 1.3910, 1e-4},
+	}
+	for _, test := range tests {
+		if got := DeltaE76(test.a, test.b); math.Abs(got-test.de76) > test.tolerate {
+			t.Errorf("Expected ΔE76(%v, %v) = %.4f, got %.4f", test.a, test.b, test.de76, got)
+		}
+		if got := DeltaE94(test.a, test.b); math.Abs(got-test.de94) > test.tolerate {
+			t.Errorf("Expected ΔE94(%v, %v) = %.4f, got %.4f", test.a, test.b, test.de94, got)
+		}
+	}
+}
+
+func TestDeltaEOK(t *testing.T) {
+	if d := DeltaEOK(RGB{0, 0, 0}, RGB{255, 255, 255}); math.Abs(d-1) > 1e-3 {
+		t.Errorf("Expected black and white to be 1 apart in OKLab, got %f", d)
+	}
+	if d := DeltaEOK(OKLab{0.5, 0.1, 0}, OKLab{0.5, -0.1, 0}); math.Abs(d-0.2) > 1e-9 {
+		t.Errorf("Expected OKLab colors to be compared without conversion, got %f", d)
+	}
+}
+
+func TestLabRoundTrip(t *testing.T) {
+	for _, ce := range getEquivalents() {
+		lab := Lab{}.FromPrecise(ce.pc).(Lab)
+		if pc := lab.ToPrecise(); !pcDeltaOk(pc, ce.pc) {
+			t.Errorf(AssertTemplate, ce.name, lab, ce.pc, pc)
+		}
+	}
+	// sRGB red is a well known reference
+	red := Lab{}.FromPrecise(PreciseColor{1, 0, 0}).(Lab)
+	if math.Abs(red.L-53.24) > 0.01 || math.Abs(red.A-80.09) > 0.01 || math.Abs(red.B-67.20) > 0.01 {
+		t.Errorf("Expected red to be lab(53.24 80.09 67.20), got %v", red)
+	}
+}
+
+func TestLabD50(t *testing.T) {
+	// CSS Color 4 gives lab(54.29% 80.82 69.9) for sRGB red
+	l, a, b := PreciseToLabD50(PreciseColor{1, 0, 0})
+	if math.Abs(l-54.29) > 0.02 || math.Abs(a-80.82) > 0.05 || math.Abs(b-69.9) > 0.05 {
+		t.Errorf("Expected red to be lab(54.29 80.82 69.9) in D50, got lab(%.2f %.2f %.2f)", l, a, b)
+	}
+	if white := LabD50ToPrecise(100, 0, 0); !pcDeltaOk(white, PreciseColor{1, 1, 1}) {
+		t.Errorf("Expected D50 white to stay white, got %v", white)
+	}
+	for _, ce := range getEquivalents() {
+		if pc := LabD50ToPrecise(PreciseToLabD50(ce.pc)); !pcDeltaOk(pc, ce.pc) {
+			t.Errorf(AssertTemplate, ce.name, "D50 Lab", ce.pc, pc)
+		}
+	}
+}
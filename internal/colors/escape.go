@@ -0,0 +1,145 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+var (
+	errUnknownEscapeStyle = errors.New("unrecognized escape style")
+	errUnknownColorDepth  = errors.New("unrecognized color depth")
+)
+
+// EscapeStyle selects how the ESC character is spelled in copied escape
+// sequences. Every consumer (shell, programming language, config file...)
+// understands a different subset of those.
+type EscapeStyle int
+
+const (
+	EscHex     EscapeStyle = iota // \x1b (printf, python, lua, C...)
+	EscE                          // \e (bash, zsh, tmux...)
+	EscOctal                      // \033 (POSIX printf, echo -e...)
+	EscUnicode                    // \u001b (JSON, JavaScript, java...)
+	EscRaw                        // The actual ESC (0x1B) byte
+)
+
+var escapeStyles = [][]string{
+	EscHex:     {"\\x1b", "hex", "x1b"},
+	EscE:       {"\\e", "e"},
+	EscOctal:   {"\\033", "octal", "033"},
+	EscUnicode: {"\\u001b", "unicode", "u001b"},
+	EscRaw:     {"raw", "byte"},
+}
+
+// ColorDepth selects which SGR color format is used to encode a color.
+type ColorDepth int
+
+const (
+	DepthTrueColor ColorDepth = iota // 38;2;r;g;b
+	Depth256                         // 38;5;n (nearest xterm-256 color)
+	Depth16                          // 30-37/90-97 (nearest ANSI color)
+)
+
+var colorDepths = [][]string{
+	DepthTrueColor: {"truecolor", "24bit", "rgb"},
+	Depth256:       {"256", "8bit", "ansi256"},
+	Depth16:        {"16", "4bit", "ansi"},
+}
+
+// EscapeOpts describes how ANSI escape sequences are written out.
+type EscapeOpts struct {
+	Style EscapeStyle
+	Depth ColorDepth
+	Reset bool // Append a reset sequence (ESC[0m), the text going before it
+}
+
+func (s EscapeStyle) String() string { return escapeStyles[s][0] }
+
+func (d ColorDepth) String() string { return colorDepths[d][0] }
+
+// EscapeStyles lists the accepted names of every escape style
+// (useful for help messages and shell completion).
+func EscapeStyles() []string {
+	return util.Aliases(escapeStyles)
+}
+
+// ColorDepths lists the accepted names of every color depth.
+func ColorDepths() []string {
+	return util.Aliases(colorDepths)
+}
+
+func ParseEscapeStyle(s string) (EscapeStyle, error) {
+	i, err := util.LookupAlias(escapeStyles, s, errUnknownEscapeStyle)
+	return EscapeStyle(i), err
+}
+
+func ParseColorDepth(s string) (ColorDepth, error) {
+	i, err := util.LookupAlias(colorDepths, s, errUnknownColorDepth)
+	return ColorDepth(i), err
+}
+
+// Esc returns the ESC character spelled in the given style.
+func (s EscapeStyle) Esc() string {
+	if s == EscRaw {
+		return "\x1b"
+	}
+	return s.String()
+}
+
+// Seq returns the SGR sequence setting the given color as either the
+// foreground or the background.
+func (o EscapeOpts) Seq(cs ColorSpace, fg bool) string {
+	return o.wrap(o.params(cs, fg))
+}
+
+// Combined returns a single SGR sequence setting both the foreground and
+// the background. A nil color resets that layer to the terminal's default.
+func (o EscapeOpts) Combined(fg, bg ColorSpace) string {
+	fgParams, bgParams := "39", "49"
+	if fg != nil {
+		fgParams = o.params(fg, true)
+	}
+	if bg != nil {
+		bgParams = o.params(bg, false)
+	}
+	return o.wrap(fgParams + ";" + bgParams)
+}
+
+func (o EscapeOpts) wrap(params string) string {
+	esc := o.Style.Esc()
+	seq := fmt.Sprintf("%s[%sm", esc, params)
+	if o.Reset {
+		seq += esc + "[0m"
+	}
+	return seq
+}
+
+func (o EscapeOpts) params(cs ColorSpace, fg bool) string {
+	mod := 38 // fg by default
+	if !fg {
+		mod += 10
+	}
+
+	switch o.Depth {
+	case Depth256:
+		i, _ := Nearest256(cs)
+		return fmt.Sprintf("%d;5;%d", mod, i)
+	case Depth16:
+		i, _ := Nearest16(cs)
+		base := mod - 8 // 30 or 40
+		if i >= 8 {
+			base += 60 // 90 or 100
+			i -= 8
+		}
+		return fmt.Sprintf("%d", base+i)
+	default:
+		p := cs.ToPrecise()
+		r := int(math.Round(p.R * 255))
+		g := int(math.Round(p.G * 255))
+		b := int(math.Round(p.B * 255))
+		return fmt.Sprintf("%d;2;%d;%d;%d", mod, r, g, b)
+	}
+}
@@ -0,0 +1,68 @@
+package colors
+
+import "testing"
+
+func TestEscapeSeq(t *testing.T) {
+	orange := RGB{255, 135, 0}
+	tests := []struct {
+		name     string
+		opts     EscapeOpts
+		fg       bool
+		expected string
+	}{
+		{"default fg", EscapeOpts{}, true, "\\x1b[38;2;255;135;0m"},
+		{"default bg", EscapeOpts{}, false, "\\x1b[48;2;255;135;0m"},
+		{"bash", EscapeOpts{Style: EscE}, true, "\\e[38;2;255;135;0m"},
+		{"octal", EscapeOpts{Style: EscOctal}, true, "\\033[38;2;255;135;0m"},
+		{"unicode", EscapeOpts{Style: EscUnicode}, true, "\\u001b[38;2;255;135;0m"},
+		{"raw", EscapeOpts{Style: EscRaw}, true, "\x1b[38;2;255;135;0m"},
+		{"reset", EscapeOpts{Reset: true}, true, "\\x1b[38;2;255;135;0m\\x1b[0m"},
+		{"256 fg", EscapeOpts{Depth: Depth256}, true, "\\x1b[38;5;208m"},
+		{"256 bg", EscapeOpts{Depth: Depth256}, false, "\\x1b[48;5;208m"},
+		{"16 fg", EscapeOpts{Depth: Depth16}, true, "\\x1b[91m"},
+		{"16 bg", EscapeOpts{Depth: Depth16}, false, "\\x1b[101m"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.opts.Seq(orange, test.fg)
+			if got != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestEscapeCombined(t *testing.T) {
+	opts := EscapeOpts{Style: EscE}
+	got := opts.Combined(RGB{255, 255, 255}, RGB{0, 0, 0})
+	expected := "\\e[38;2;255;255;255;48;2;0;0;0m"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	got = opts.Combined(RGB{255, 255, 255}, nil)
+	expected = "\\e[38;2;255;255;255;49m"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestParseEscapeOpts(t *testing.T) {
+	for _, name := range EscapeStyles() {
+		if _, err := ParseEscapeStyle(name); err != nil {
+			t.Errorf("Listed escape style %q failed to parse: %v", name, err)
+		}
+	}
+	for _, name := range ColorDepths() {
+		if _, err := ParseColorDepth(name); err != nil {
+			t.Errorf("Listed color depth %q failed to parse: %v", name, err)
+		}
+	}
+	if _, err := ParseEscapeStyle("\\X1B"); err != nil {
+		t.Errorf("Escape style names should be case insensitive: %v", err)
+	}
+	if _, err := ParseEscapeStyle("nope"); err == nil {
+		t.Error("Expected an error for an unknown escape style")
+	}
+}
@@ -0,0 +1,229 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+var (
+	errUnknownInterp = errors.New("unrecognized interpolation space")
+	errUnknownHueDir = errors.New("unrecognized hue interpolation direction")
+)
+
+// Interpolation is the color space gradients are blended in
+type Interpolation int
+
+const (
+	InterpSRGB   Interpolation = iota // Gamma encoded sRGB (legacy CSS behavior)
+	InterpLinear                      // Linear light sRGB
+	InterpOKLab
+	InterpOKLCH
+)
+
+// The first name of each entry is the CSS <color-interpolation-method>
+var interpolations = [][]string{
+	InterpSRGB:   {"srgb", "rgb"},
+	InterpLinear: {"srgb-linear", "linear"},
+	InterpOKLab:  {"oklab"},
+	InterpOKLCH:  {"oklch"},
+}
+
+func (i Interpolation) String() string { return interpolations[i][0] }
+
+func Interpolations() []Interpolation {
+	return []Interpolation{InterpSRGB, InterpLinear, InterpOKLab, InterpOKLCH}
+}
+
+func InterpolationNames() []string { return util.Aliases(interpolations) }
+
+func ParseInterpolation(s string) (Interpolation, error) {
+	i, err := util.LookupAlias(interpolations, s, errUnknownInterp)
+	return Interpolation(i), err
+}
+
+// HueDirection is how hues are interpolated in polar spaces (OKLCH). The
+// names and behaviors match CSS Color 4.
+type HueDirection int
+
+const (
+	HueShorter HueDirection = iota
+	HueLonger
+	HueIncreasing
+	HueDecreasing
+)
+
+var hueDirections = [][]string{
+	HueShorter:    {"shorter"},
+	HueLonger:     {"longer"},
+	HueIncreasing: {"increasing"},
+	HueDecreasing: {"decreasing"},
+}
+
+func (h HueDirection) String() string { return hueDirections[h][0] }
+
+func HueDirections() []HueDirection {
+	return []HueDirection{HueShorter, HueLonger, HueIncreasing, HueDecreasing}
+}
+
+func HueDirectionNames() []string { return util.Aliases(hueDirections) }
+
+func ParseHueDirection(s string) (HueDirection, error) {
+	i, err := util.LookupAlias(hueDirections, s, errUnknownHueDir)
+	return HueDirection(i), err
+}
+
+// Stop is a gradient color at a position between 0 and 1
+type Stop struct {
+	Color ColorSpace
+	Pos   float64
+}
+
+// Gradient blends colors between stops. Stops are expected to be sorted by
+// position (see Sort).
+type Gradient struct {
+	Stops []Stop
+	Space Interpolation
+	Hue   HueDirection // Only used by OKLCH
+}
+
+// Sort orders the stops by position, keeping the order of equal ones.
+func (g Gradient) Sort() {
+	slices.SortStableFunc(g.Stops, func(a, b Stop) int {
+		switch {
+		case a.Pos < b.Pos:
+			return -1
+		case a.Pos > b.Pos:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// At returns the gradient's color at t (from 0 to 1). Before the first stop
+// and after the last one, the color of the closest stop is used.
+func (g Gradient) At(t float64) PreciseColor {
+	if len(g.Stops) == 0 {
+		return PreciseColor{}
+	}
+	first, last := g.Stops[0], g.Stops[len(g.Stops)-1]
+	if t <= first.Pos {
+		return first.Color.ToPrecise()
+	}
+	if t >= last.Pos {
+		return last.Color.ToPrecise()
+	}
+	for i := 1; i < len(g.Stops); i++ {
+		a, b := g.Stops[i-1], g.Stops[i]
+		if t > b.Pos {
+			continue
+		}
+		if b.Pos == a.Pos {
+			return b.Color.ToPrecise()
+		}
+		return g.blend(a.Color, b.Color, (t-a.Pos)/(b.Pos-a.Pos))
+	}
+	return last.Color.ToPrecise()
+}
+
+// Sample returns n colors evenly spread from the start to the end
+func (g Gradient) Sample(n int) []PreciseColor {
+	if n == 1 {
+		return []PreciseColor{g.At(0)}
+	}
+	samples := make([]PreciseColor, n)
+	for i := range samples {
+		samples[i] = g.At(float64(i) / float64(n-1))
+	}
+	return samples
+}
+
+func (g Gradient) blend(a, b ColorSpace, t float64) PreciseColor {
+	mix := func(x, y float64) float64 { return x + (y-x)*t }
+	switch g.Space {
+	case InterpLinear:
+		pa, pb := a.ToPrecise(), b.ToPrecise()
+		return PreciseColor{
+			R: linearToSRGB(mix(srgbToLinear(pa.R), srgbToLinear(pb.R))),
+			G: linearToSRGB(mix(srgbToLinear(pa.G), srgbToLinear(pb.G))),
+			B: linearToSRGB(mix(srgbToLinear(pa.B), srgbToLinear(pb.B))),
+		}
+	case InterpOKLab:
+		la, lb := toOKLab(a), toOKLab(b)
+		return OKLab{mix(la.L, lb.L), mix(la.A, lb.A), mix(la.B, lb.B)}.ToPrecise()
+	case InterpOKLCH:
+		ca, cb := toOKLCH(a), toOKLCH(b)
+		ha, hb := g.Hue.fixup(ca, cb)
+		return OKLCH{
+			L: mix(ca.L, cb.L),
+			C: mix(ca.C, cb.C),
+			H: math.Mod(mix(ha, hb)+360, 360),
+		}.ToPrecise()
+	default:
+		pa, pb := a.ToPrecise(), b.ToPrecise()
+		return PreciseColor{mix(pa.R, pb.R), mix(pa.G, pb.G), mix(pa.B, pb.B)}
+	}
+}
+
+// fixup returns the hues to interpolate between, adjusted so that going from
+// the first to the second follows the direction. Grays have no hue: they
+// take the other color's instead so the gradient doesn't sweep the wheel.
+func (h HueDirection) fixup(a, b OKLCH) (float64, float64) {
+	const achromatic = 1e-4
+	ha, hb := a.H, b.H
+	switch {
+	case a.C < achromatic && b.C < achromatic:
+		return ha, ha
+	case a.C < achromatic:
+		ha = hb
+	case b.C < achromatic:
+		hb = ha
+	}
+
+	d := hb - ha
+	switch h {
+	case HueShorter:
+		if d > 180 {
+			ha += 360
+		} else if d < -180 {
+			hb += 360
+		}
+	case HueLonger:
+		if 0 < d && d < 180 {
+			ha += 360
+		} else if -180 < d && d <= 0 {
+			hb += 360
+		}
+	case HueIncreasing:
+		if d < 0 {
+			hb += 360
+		}
+	case HueDecreasing:
+		if d > 0 {
+			ha += 360
+		}
+	}
+	return ha, hb
+}
+
+// CSS writes the gradient as a left to right CSS linear-gradient()
+func (g Gradient) CSS() string {
+	method := "in " + g.Space.String()
+	if g.Space == InterpOKLCH && g.Hue != HueShorter {
+		method += " " + g.Hue.String() + " hue"
+	}
+	parts := []string{"to right " + method}
+	for _, s := range g.Stops {
+		parts = append(parts, fmt.Sprintf("%s %s%%", Hex(s.Color), formatPercent(s.Pos)))
+	}
+	return "linear-gradient(" + strings.Join(parts, ", ") + ")"
+}
+
+func formatPercent(pos float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", pos*100), "0"), ".")
+}
@@ -0,0 +1,89 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGradientAt(t *testing.T) {
+	black, white := RGB{0, 0, 0}, RGB{255, 255, 255}
+	g := Gradient{Stops: []Stop{{black, 0}, {white, 1}}}
+
+	tests := []struct {
+		space    Interpolation
+		expected float64 // Red channel halfway through
+	}{
+		{InterpSRGB, 0.5},
+		{InterpLinear, linearToSRGB(0.5)},
+		{InterpOKLab, OKLCH{L: 0.5}.ToPrecise().R},
+		{InterpOKLCH, OKLCH{L: 0.5}.ToPrecise().R},
+	}
+	for _, test := range tests {
+		g.Space = test.space
+		got := g.At(0.5)
+		if math.Abs(got.R-test.expected) > 1e-3 || math.Abs(got.R-got.G) > 1e-3 || math.Abs(got.G-got.B) > 1e-3 {
+			t.Errorf("Expected a %.4f gray halfway through in %v, got %v", test.expected, test.space, got)
+		}
+		if got := g.At(-1); got != black.ToPrecise() {
+			t.Errorf("Expected the first stop's color before it, got %v", got)
+		}
+		if got := g.At(2); got != white.ToPrecise() {
+			t.Errorf("Expected the last stop's color after it, got %v", got)
+		}
+	}
+}
+
+func TestGradientHue(t *testing.T) {
+	a := OKLCH{L: 0.7, C: 0.1, H: 350}
+	b := OKLCH{L: 0.7, C: 0.1, H: 30}
+	tests := []struct {
+		dir      HueDirection
+		expected float64 // Hue halfway through
+	}{
+		{HueShorter, 10},
+		{HueLonger, 190},
+		{HueIncreasing, 10},
+		{HueDecreasing, 190},
+	}
+	for _, test := range tests {
+		g := Gradient{Stops: []Stop{{a, 0}, {b, 1}}, Space: InterpOKLCH, Hue: test.dir}
+		ca, cb := test.dir.fixup(a, b)
+		mid := math.Mod((ca+cb)/2+360, 360)
+		if math.Abs(mid-test.expected) > 1e-9 {
+			t.Errorf("Expected %v hues to meet at %.0f, got %.2f", test.dir, test.expected, mid)
+		}
+		if got := Hex(g.At(0.5)); got != Hex(OKLCH{L: 0.7, C: 0.1, H: test.expected}) {
+			t.Errorf("Expected the %v midpoint to be %s, got %s", test.dir, Hex(OKLCH{L: 0.7, C: 0.1, H: test.expected}), got)
+		}
+	}
+
+	// Grays take the hue of the other color
+	gray := OKLCH{L: 0.7}
+	if ha, hb := HueShorter.fixup(gray, b); ha != hb {
+		t.Errorf("Expected a gray to borrow the other hue, got %.1f and %.1f", ha, hb)
+	}
+}
+
+func TestGradientSampleAndCSS(t *testing.T) {
+	g := Gradient{
+		Stops: []Stop{{RGB{255, 0, 0}, 0.5}, {RGB{0, 0, 255}, 1}, {RGB{0, 255, 0}, 0}},
+		Space: InterpOKLCH,
+		Hue:   HueLonger,
+	}
+	g.Sort()
+	samples := g.Sample(3)
+	if len(samples) != 3 || Hex(samples[0]) != "#00FF00" || Hex(samples[1]) != "#FF0000" || Hex(samples[2]) != "#0000FF" {
+		t.Errorf("Expected stops to be sampled in order, got %v", samples)
+	}
+
+	expected := "linear-gradient(to right in oklch longer hue, #00FF00 0%, #FF0000 50%, #0000FF 100%)"
+	if css := g.CSS(); css != expected {
+		t.Errorf("Expected %s, got %s", expected, css)
+	}
+	g.Space = InterpLinear
+	g.Stops[1].Pos = 0.125
+	expected = "linear-gradient(to right in srgb-linear, #00FF00 0%, #FF0000 12.5%, #0000FF 100%)"
+	if css := g.CSS(); css != expected {
+		t.Errorf("Expected %s, got %s", expected, css)
+	}
+}
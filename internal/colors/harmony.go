@@ -0,0 +1,129 @@
+package colors
+
+import "math"
+
+// Harmony is a color scheme derived from a base color by rotating its hue
+// (or, for Monochromatic, changing its lightness).
+type Harmony int
+
+const (
+	Complementary Harmony = iota
+	SplitComplementary
+	Triadic
+	Tetradic
+	Analogous
+	Monochromatic
+)
+
+var harmonies = []string{
+	Complementary:      "complementary",
+	SplitComplementary: "split complementary",
+	Triadic:            "triadic",
+	Tetradic:           "tetradic",
+	Analogous:          "analogous",
+	Monochromatic:      "monochromatic",
+}
+
+func (h Harmony) String() string { return harmonies[h] }
+
+// Harmonies lists every harmony, in the order they are cycled through.
+func Harmonies() []Harmony {
+	return []Harmony{Complementary, SplitComplementary, Triadic, Tetradic, Analogous, Monochromatic}
+}
+
+// Hue offsets (in degrees) of each harmony, the base color is always at 0.
+// Tetradic uses the rectangle variant (two complementary pairs 60° apart).
+var harmonyOffsets = map[Harmony][]float64{
+	Complementary:      {0, 180},
+	SplitComplementary: {0, 150, 210},
+	Triadic:            {0, 120, 240},
+	Tetradic:           {0, 60, 180, 240},
+	Analogous:          {-30, 0, 30},
+}
+
+// HarmonySpace is the color space hues are rotated in. HSL matches most
+// color wheels while OKLCH keeps the perceived lightness of every swatch.
+type HarmonySpace int
+
+const (
+	HarmonyHSL HarmonySpace = iota
+	HarmonyOKLCH
+)
+
+var harmonySpaces = []string{
+	HarmonyHSL:   "HSL",
+	HarmonyOKLCH: "OKLCH",
+}
+
+func (s HarmonySpace) String() string { return harmonySpaces[s] }
+
+// Generate builds the harmony around cs. The colors are returned in the
+// given space (HSL or OKLCH) and always include cs itself.
+func (h Harmony) Generate(cs ColorSpace, space HarmonySpace) []ColorSpace {
+	if h == Monochromatic {
+		return monochromatic(cs, space)
+	}
+
+	offsets := harmonyOffsets[h]
+	scheme := make([]ColorSpace, len(offsets))
+	switch space {
+	case HarmonyOKLCH:
+		base := toOKLCH(cs)
+		for i, off := range offsets {
+			c := base
+			c.H = math.Mod(base.H+off+360, 360)
+			scheme[i] = c.MapToGamut()
+		}
+	default:
+		base := toHSL(cs)
+		for i, off := range offsets {
+			c := base
+			c.H = (base.H + int(off) + 360) % 360
+			scheme[i] = c
+		}
+	}
+	return scheme
+}
+
+// monochromatic keeps the hue and saturation/chroma of cs and spreads the
+// lightness: two darker steps, cs, then two lighter steps.
+func monochromatic(cs ColorSpace, space HarmonySpace) []ColorSpace {
+	spread := func(l, max float64) []float64 {
+		return []float64{l / 3, l * 2 / 3, l, l + (max-l)/3, l + (max-l)*2/3}
+	}
+
+	scheme := []ColorSpace{}
+	switch space {
+	case HarmonyOKLCH:
+		base := toOKLCH(cs)
+		for _, l := range spread(base.L, 1) {
+			c := base
+			c.L = l
+			scheme = append(scheme, c.MapToGamut())
+		}
+	default:
+		base := toHSL(cs)
+		for _, l := range spread(float64(base.L), 100) {
+			c := base
+			c.L = int(math.Round(l))
+			scheme = append(scheme, c)
+		}
+	}
+	return scheme
+}
+
+// toOKLCH and toHSL skip the conversion when cs is already in that space
+// so rotations don't accumulate rounding errors.
+func toOKLCH(cs ColorSpace) OKLCH {
+	if o, ok := cs.(OKLCH); ok {
+		return o
+	}
+	return OKLCH{}.FromPrecise(cs.ToPrecise()).(OKLCH)
+}
+
+func toHSL(cs ColorSpace) HSL {
+	if h, ok := cs.(HSL); ok {
+		return h
+	}
+	return HSL{}.FromPrecise(cs.ToPrecise()).(HSL)
+}
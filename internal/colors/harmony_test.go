@@ -0,0 +1,65 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHarmonyHSL(t *testing.T) {
+	base := HSL{H: 340, S: 60, L: 50}
+	tests := []struct {
+		harmony Harmony
+		hues    []int
+	}{
+		{Complementary, []int{340, 160}},
+		{SplitComplementary, []int{340, 130, 190}},
+		{Triadic, []int{340, 100, 220}},
+		{Tetradic, []int{340, 40, 160, 220}},
+		{Analogous, []int{310, 340, 10}},
+	}
+	for _, test := range tests {
+		scheme := test.harmony.Generate(base, HarmonyHSL)
+		if len(scheme) != len(test.hues) {
+			t.Errorf("Expected %d %v colors, got %d", len(test.hues), test.harmony, len(scheme))
+			continue
+		}
+		for i, c := range scheme {
+			hsl := c.(HSL)
+			if hsl.H != test.hues[i] || hsl.S != base.S || hsl.L != base.L {
+				t.Errorf("Expected %v color %d to be hsl(%d, 60%%, 50%%), got %v", test.harmony, i, test.hues[i], hsl)
+			}
+		}
+	}
+
+	mono := Monochromatic.Generate(base, HarmonyHSL)
+	expected := []int{17, 33, 50, 67, 83}
+	for i, c := range mono {
+		if hsl := c.(HSL); hsl.L != expected[i] || hsl.H != base.H {
+			t.Errorf("Expected monochromatic color %d to have L=%d, got %v", i, expected[i], hsl)
+		}
+	}
+}
+
+func TestHarmonyOKLCH(t *testing.T) {
+	base := OKLCH{L: 0.6, C: 0.1, H: 350}
+	for _, h := range Harmonies() {
+		scheme := h.Generate(base, HarmonyOKLCH)
+		found := false
+		for _, c := range scheme {
+			o := c.(OKLCH)
+			if !o.InGamut() {
+				t.Errorf("Expected %v colors to be displayable, got %v", h, o)
+			}
+			if h != Monochromatic && math.Abs(o.L-base.L) > 1e-9 {
+				t.Errorf("Expected %v to keep the lightness, got %v", h, o)
+			}
+			found = found || (math.Abs(o.L-base.L) < 1e-9 && math.Abs(o.H-base.H) < 1e-9)
+		}
+		if !found {
+			t.Errorf("Expected %v to include the base color", h)
+		}
+	}
+	if c := Complementary.Generate(base, HarmonyOKLCH)[1].(OKLCH); math.Abs(c.H-170) > 1e-9 {
+		t.Errorf("Expected the complement to have a hue of 170, got %v", c)
+	}
+}
@@ -0,0 +1,71 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// HSV is a color as hue, saturation and value, the coordinates of the
+// square pickers found in graphics apps.
+type HSV struct {
+	H float64 // Hue 0-360 degrees
+	S float64 // Saturation 0-1
+	V float64 // Value 0-1
+}
+
+func (h HSV) String() string {
+	return fmt.Sprintf("hsv(%.0f, %.0f%%, %.0f%%)", h.H, h.S*100, h.V*100)
+}
+
+func (h HSV) ToPrecise() PreciseColor {
+	hue := math.Mod(h.H, 360)
+	if hue < 0 {
+		hue += 360
+	}
+	chroma := h.V * h.S
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := h.V - chroma
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return PreciseColor{R: r + m, G: g + m, B: b + m}
+}
+
+func (h HSV) FromPrecise(p PreciseColor) ColorSpace {
+	max := math.Max(math.Max(p.R, p.G), p.B)
+	min := math.Min(math.Min(p.R, p.G), p.B)
+	delta := max - min
+
+	var hue, sat float64
+	if max > 0 {
+		sat = delta / max
+	}
+	if delta >= 1e-4 {
+		switch max {
+		case p.R:
+			hue = math.Mod((p.G-p.B)/delta, 6)
+		case p.G:
+			hue = (p.B-p.R)/delta + 2
+		default:
+			hue = (p.R-p.G)/delta + 4
+		}
+		hue *= 60
+		if hue < 0 {
+			hue += 360
+		}
+	}
+	return HSV{H: hue, S: sat, V: max}
+}
@@ -0,0 +1,35 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHSV(t *testing.T) {
+	tests := []struct {
+		name string
+		pc   PreciseColor
+		hsv  HSV
+	}{
+		{"Black", PreciseColor{0, 0, 0}, HSV{0, 0, 0}},
+		{"White", PreciseColor{1, 1, 1}, HSV{0, 0, 1}},
+		{"Red", PreciseColor{1, 0, 0}, HSV{0, 1, 1}},
+		{"Yellow", PreciseColor{1, 1, 0}, HSV{60, 1, 1}},
+		{"Cyan", PreciseColor{0, 1, 1}, HSV{180, 1, 1}},
+		{"Dark magenta", PreciseColor{0.5, 0, 0.5}, HSV{300, 1, 0.5}},
+		{"Pale blue", PreciseColor{0.5, 0.5, 1}, HSV{240, 0.5, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hsv.ToPrecise(); !pcDeltaOk(got, tt.pc) {
+				t.Errorf(AssertTemplate, tt.hsv, "PreciseColor", tt.pc, got)
+			}
+			got := HSV{}.FromPrecise(tt.pc).(HSV)
+			if math.Abs(got.H-tt.hsv.H) > 0.5 ||
+				math.Abs(got.S-tt.hsv.S) > PCmaxDelta ||
+				math.Abs(got.V-tt.hsv.V) > PCmaxDelta {
+				t.Errorf(AssertTemplate, tt.pc, "HSV", tt.hsv, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// D65 reference white (2° observer), used by sRGB
+const (
+	whiteX = 0.95047
+	whiteY = 1.00000
+	whiteZ = 1.08883
+)
+
+// Lab is the CIE 1976 L*a*b* color space relative to the D65 white point.
+// It is what the classic deltaE formulas (CIE76, CIE94, CIEDE2000) expect.
+type Lab struct {
+	L float64 // Lightness 0-100
+	A float64 // Green (-) to red (+), roughly -128 to 127
+	B float64 // Blue (-) to yellow (+), roughly -128 to 127
+}
+
+func (l Lab) String() string {
+	return fmt.Sprintf("lab(%.2f%% %.2f %.2f)", l.L, l.A, l.B)
+}
+
+func (l Lab) ToPrecise() PreciseColor {
+	fy := (l.L + 16) / 116
+	fx := fy + l.A/500
+	fz := fy - l.B/200
+
+	x := whiteX * labFInv(fx)
+	y := whiteY * labFInv(fy)
+	z := whiteZ * labFInv(fz)
+
+	return xyzToPrecise(x, y, z)
+}
+
+func (l Lab) FromPrecise(p PreciseColor) ColorSpace {
+	x, y, z := preciseToXYZ(p)
+
+	fx := labF(x / whiteX)
+	fy := labF(y / whiteY)
+	fz := labF(z / whiteZ)
+
+	return Lab{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+// toLab avoids a round-trip through sRGB (which clamps out of gamut values)
+// when the color is already expressed in Lab.
+func toLab(cs ColorSpace) Lab {
+	if l, ok := cs.(Lab); ok {
+		return l
+	}
+	return Lab{}.FromPrecise(cs.ToPrecise()).(Lab)
+}
+
+func labF(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29.0
+}
+
+func labFInv(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta {
+		return t * t * t
+	}
+	return 3 * delta * delta * (t - 4.0/29.0)
+}
+
+// preciseToXYZ converts sRGB to CIE XYZ (D65)
+func preciseToXYZ(p PreciseColor) (x, y, z float64) {
+	r := srgbToLinear(p.R)
+	g := srgbToLinear(p.G)
+	b := srgbToLinear(p.B)
+
+	x = 0.4124564390896922*r + 0.357576077643909*g + 0.18043748326639894*b
+	y = 0.21267285140562253*r + 0.715152155287818*g + 0.07217499330655958*b
+	z = 0.019333895582329317*r + 0.11919202588130297*g + 0.9503040785363677*b
+	return x, y, z
+}
+
+// xyzToPrecise converts CIE XYZ (D65) to sRGB, clamping out of gamut values
+func xyzToPrecise(x, y, z float64) PreciseColor {
+	r := 3.2406254773200533*x - 1.5372079722103187*y - 0.4986285986588718*z
+	g := -0.9689307147293197*x + 1.8757560608852415*y + 0.041517523842953964*z
+	b := 0.055710120445510616*x - 0.2040259135167538*y + 1.0569715142428784*z
+
+	return PreciseColor{
+		R: math.Max(0, math.Min(1, linearToSRGB(r))),
+		G: math.Max(0, math.Min(1, linearToSRGB(g))),
+		B: math.Max(0, math.Min(1, linearToSRGB(b))),
+	}
+}
+
+// D50 reference white, used by print oriented formats (ex: Adobe swatches)
+const (
+	d50X = 0.96422
+	d50Y = 1.00000
+	d50Z = 0.82521
+)
+
+// LabD50ToPrecise converts Lab values relative to D50 to sRGB. The white
+// point is adapted to sRGB's D65 with the Bradford transform.
+func LabD50ToPrecise(l, a, b float64) PreciseColor {
+	fy := (l + 16) / 116
+	x := d50X * labFInv(fy+a/500)
+	y := d50Y * labFInv(fy)
+	z := d50Z * labFInv(fy-b/200)
+
+	return xyzToPrecise(
+		0.9555766*x-0.0230393*y+0.0631636*z,
+		-0.0282895*x+1.0099416*y+0.0210077*z,
+		0.0122982*x-0.0204830*y+1.3299098*z,
+	)
+}
+
+// PreciseToLabD50 is the inverse of LabD50ToPrecise
+func PreciseToLabD50(p PreciseColor) (l, a, b float64) {
+	x65, y65, z65 := preciseToXYZ(p)
+	x := 1.0478112*x65 + 0.0228866*y65 - 0.0501270*z65
+	y := 0.0295424*x65 + 0.9904844*y65 - 0.0170491*z65
+	z := -0.0092345*x65 + 0.0150436*y65 + 0.7521316*z65
+
+	fx := labF(x / d50X)
+	fy := labF(y / d50Y)
+	fz := labF(z / d50Z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
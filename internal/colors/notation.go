@@ -0,0 +1,59 @@
+package colors
+
+import (
+	"errors"
+
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+var errUnknownNotation = errors.New("unrecognized color notation")
+
+// Notation is a way of writing a color down, one for each format the color
+// input understands.
+type Notation int
+
+const (
+	NotationHex Notation = iota
+	NotationRGB
+	NotationHSL
+	NotationCMYK
+	NotationOKLCH
+)
+
+var notations = [][]string{
+	NotationHex:   {"hex"},
+	NotationRGB:   {"rgb"},
+	NotationHSL:   {"hsl"},
+	NotationCMYK:  {"cmyk"},
+	NotationOKLCH: {"oklch"},
+}
+
+func (n Notation) String() string { return notations[n][0] }
+
+func Notations() []Notation {
+	return []Notation{NotationHex, NotationRGB, NotationHSL, NotationCMYK, NotationOKLCH}
+}
+
+func NotationNames() []string { return util.Aliases(notations) }
+
+func ParseNotation(s string) (Notation, error) {
+	i, err := util.LookupAlias(notations, s, errUnknownNotation)
+	return Notation(i), err
+}
+
+// Format writes cs using the notation
+func (n Notation) Format(cs ColorSpace) string {
+	p := cs.ToPrecise()
+	switch n {
+	case NotationRGB:
+		return RGB{}.FromPrecise(p).(RGB).String()
+	case NotationHSL:
+		return toHSL(cs).String()
+	case NotationCMYK:
+		return CMYK{}.FromPrecise(p).(CMYK).String()
+	case NotationOKLCH:
+		return toOKLCH(cs).String()
+	default:
+		return Hex(cs)
+	}
+}
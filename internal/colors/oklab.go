@@ -0,0 +1,67 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// OKLab is the cartesian form of OKLCH. Euclidean distances in this space
+// closely match perceived color differences which makes it the go-to space
+// for color matching.
+type OKLab struct {
+	L float64 // Lightness 0-1
+	A float64 // Green (-) to red (+), roughly -0.4 to 0.4
+	B float64 // Blue (-) to yellow (+), roughly -0.4 to 0.4
+}
+
+func (o OKLab) String() string {
+	return fmt.Sprintf("oklab(%.1f%% %.3f %.3f)", o.L*100, o.A, o.B)
+}
+
+func (o OKLab) ToPrecise() PreciseColor {
+	return o.ToOKLCH().ToPrecise()
+}
+
+func (o OKLab) FromPrecise(p PreciseColor) ColorSpace {
+	lch := OKLCH{}.FromPrecise(p).(OKLCH)
+	hRad := lch.H * math.Pi / 180.0
+	return OKLab{
+		L: lch.L,
+		A: lch.C * math.Cos(hRad),
+		B: lch.C * math.Sin(hRad),
+	}
+}
+
+func (o OKLab) ToOKLCH() OKLCH {
+	hue := 0.0
+	chroma := math.Hypot(o.A, o.B)
+	if chroma >= 1e-4 {
+		hue = math.Atan2(o.B, o.A) * 180.0 / math.Pi
+		if hue < 0 {
+			hue += 360
+		}
+	}
+	return OKLCH{L: o.L, C: chroma, H: hue}
+}
+
+// DeltaEOK returns the euclidean distance between two colors in OKLab.
+// A difference of roughly 0.02 is considered barely noticeable.
+func DeltaEOK(a, b ColorSpace) float64 {
+	return toOKLab(a).dist(toOKLab(b))
+}
+
+// toOKLab avoids a lossy round-trip through sRGB for OKLab colors
+func toOKLab(cs ColorSpace) OKLab {
+	if o, ok := cs.(OKLab); ok {
+		return o
+	}
+	return OKLab{}.FromPrecise(cs.ToPrecise()).(OKLab)
+}
+
+func (o OKLab) dist(other OKLab) float64 {
+	return math.Sqrt(
+		(o.L-other.L)*(o.L-other.L) +
+			(o.A-other.A)*(o.A-other.A) +
+			(o.B-other.B)*(o.B-other.B),
+	)
+}
@@ -16,16 +16,34 @@ func (o OKLCH) String() string {
 }
 
 func (o OKLCH) ToPrecise() PreciseColor {
+	rLinear, gLinear, bLinear := o.toLinear()
+
+	// Apply gamma correction (sRGB curve)
+	r := linearToSRGB(rLinear)
+	g := linearToSRGB(gLinear)
+	bVal := linearToSRGB(bLinear)
+
+	// Clamp to [0,1] range
+	r = math.Max(0, math.Min(1, r))
+	g = math.Max(0, math.Min(1, g))
+	bVal = math.Max(0, math.Min(1, bVal))
+
+	return PreciseColor{R: r, G: g, B: bVal}
+}
+
+// toLinear converts the color to linear sRGB without clamping so callers
+// can tell when a color falls outside of the sRGB gamut.
+func (o OKLCH) toLinear() (r, g, b float64) {
 	// Convert OKLCH to Oklab first
 	hRad := o.H * math.Pi / 180.0
 	a := o.C * math.Cos(hRad)
-	b := o.C * math.Sin(hRad)
+	bAxis := o.C * math.Sin(hRad)
 
 	// Convert Oklab to linear RGB using the conversion matrices from Wikipedia
 	// M2^-1 matrix (Oklab to l'm's')
-	lPrime := 0.9999999984505197*o.L + 0.39633779217376786*a + 0.2158037580607588*b
-	mPrime := 1.0000000088817607*o.L - 0.10556134232365635*a - 0.06385417477170591*b
-	sPrime := 1.0000000546724108*o.L - 0.08948418209496575*a - 1.2914855378640917*b
+	lPrime := 0.9999999984505197*o.L + 0.39633779217376786*a + 0.2158037580607588*bAxis
+	mPrime := 1.0000000088817607*o.L - 0.10556134232365635*a - 0.06385417477170591*bAxis
+	sPrime := 1.0000000546724108*o.L - 0.08948418209496575*a - 1.2914855378640917*bAxis
 
 	// Apply cube (inverse of cube root)
 	l := lPrime * lPrime * lPrime
@@ -38,21 +56,37 @@ func (o OKLCH) ToPrecise() PreciseColor {
 	z := -0.07637294974672142*l - 0.4214933239627914*m + 1.5869240244272418*s
 
 	// Convert XYZ to linear RGB (sRGB matrix)
-	rLinear := 3.2406254773200533*x - 1.5372079722103187*y - 0.4986285986588718*z
-	gLinear := -0.9689307147293197*x + 1.8757560608852415*y + 0.041517523842953964*z
-	bLinear := 0.055710120445510616*x - 0.2040259135167538*y + 1.0569715142428784*z
-
-	// Apply gamma correction (sRGB curve)
-	r := linearToSRGB(rLinear)
-	g := linearToSRGB(gLinear)
-	bVal := linearToSRGB(bLinear)
+	r = 3.2406254773200533*x - 1.5372079722103187*y - 0.4986285986588718*z
+	g = -0.9689307147293197*x + 1.8757560608852415*y + 0.041517523842953964*z
+	b = 0.055710120445510616*x - 0.2040259135167538*y + 1.0569715142428784*z
+	return r, g, b
+}
 
-	// Clamp to [0,1] range
-	r = math.Max(0, math.Min(1, r))
-	g = math.Max(0, math.Min(1, g))
-	bVal = math.Max(0, math.Min(1, bVal))
+// InGamut reports whether the color can be displayed in sRGB as-is.
+func (o OKLCH) InGamut() bool {
+	const eps = 1e-6
+	r, g, b := o.toLinear()
+	return r >= -eps && r <= 1+eps &&
+		g >= -eps && g <= 1+eps &&
+		b >= -eps && b <= 1+eps
+}
 
-	return PreciseColor{R: r, G: g, B: bVal}
+// MapToGamut brings the color into the sRGB gamut by lowering its chroma
+// while keeping its lightness and hue untouched.
+func (o OKLCH) MapToGamut() OKLCH {
+	if o.InGamut() {
+		return o
+	}
+	lo, hi := 0.0, o.C
+	for range 24 {
+		mid := (lo + hi) / 2
+		if (OKLCH{L: o.L, C: mid, H: o.H}).InGamut() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return OKLCH{L: o.L, C: lo, H: o.H}
 }
 
 func (o OKLCH) FromPrecise(p PreciseColor) ColorSpace {
@@ -0,0 +1,102 @@
+package colors
+
+import "math"
+
+// ansi16 holds the default xterm values of the 16 basic ANSI colors.
+// Terminals are free to remap those, so they are only an approximation
+// of what the user will actually see.
+var ansi16 = [16]RGB{
+	{0, 0, 0},       // black
+	{205, 0, 0},     // red
+	{0, 205, 0},     // green
+	{205, 205, 0},   // yellow
+	{0, 0, 238},     // blue
+	{205, 0, 205},   // magenta
+	{0, 205, 205},   // cyan
+	{229, 229, 229}, // white
+	{127, 127, 127}, // bright black
+	{255, 0, 0},     // bright red
+	{0, 255, 0},     // bright green
+	{255, 255, 0},   // bright yellow
+	{92, 92, 255},   // bright blue
+	{255, 0, 255},   // bright magenta
+	{0, 255, 255},   // bright cyan
+	{255, 255, 255}, // bright white
+}
+
+var ansi16Names = [16]string{
+	"black", "red", "green", "yellow",
+	"blue", "magenta", "cyan", "white",
+	"bright black", "bright red", "bright green", "bright yellow",
+	"bright blue", "bright magenta", "bright cyan", "bright white",
+}
+
+// Levels used by each channel of the 6x6x6 xterm color cube (16-231)
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// Xterm256 returns the default xterm value of the given palette index.
+// Indices outside of 0-255 are clamped.
+func Xterm256(i int) RGB {
+	switch {
+	case i < 0:
+		i = 0
+	case i > 255:
+		i = 255
+	}
+	switch {
+	case i < 16:
+		return ansi16[i]
+	case i < 232:
+		i -= 16
+		return RGB{
+			R: cubeLevels[i/36],
+			G: cubeLevels[(i/6)%6],
+			B: cubeLevels[i%6],
+		}
+	default:
+		v := 8 + (i-232)*10
+		return RGB{R: v, G: v, B: v}
+	}
+}
+
+// AnsiName returns the conventional name of one of the 16 basic colors.
+func AnsiName(i int) string {
+	if i < 0 || i > 15 {
+		return ""
+	}
+	return ansi16Names[i]
+}
+
+// OKLab values of the default palette, used for nearest color lookups
+var xtermLab [256]OKLab
+
+func init() {
+	for i := range xtermLab {
+		xtermLab[i] = OKLab{}.FromPrecise(Xterm256(i).ToPrecise()).(OKLab)
+	}
+}
+
+// Nearest256 returns the xterm-256 index perceptually closest to the given
+// color along with its distance (see DeltaEOK). Only the cube and grayscale
+// ramp (16-255) are considered as the first 16 entries are usually remapped
+// by the terminal's theme.
+func Nearest256(cs ColorSpace) (int, float64) {
+	return nearestIndex(cs, 16, 256)
+}
+
+// Nearest16 returns the basic ANSI color (0-15) perceptually closest to the
+// given color along with its distance (see DeltaEOK).
+func Nearest16(cs ColorSpace) (int, float64) {
+	return nearestIndex(cs, 0, 16)
+}
+
+func nearestIndex(cs ColorSpace, from, to int) (int, float64) {
+	target := toOKLab(cs)
+	best, bestDist := from, math.Inf(1)
+	for i := from; i < to; i++ {
+		if d := target.dist(xtermLab[i]); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best, bestDist
+}
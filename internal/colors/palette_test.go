@@ -0,0 +1,40 @@
+package colors
+
+import "testing"
+
+func TestNearestExactMatches(t *testing.T) {
+	for i := 16; i < 256; i++ {
+		idx, dist := Nearest256(Xterm256(i))
+		if Xterm256(idx) != Xterm256(i) || dist > 1e-9 {
+			t.Errorf("Expected palette entry %d to match itself, got %d (distance %f)", i, idx, dist)
+		}
+	}
+	for i := 0; i < 16; i++ {
+		idx, dist := Nearest16(Xterm256(i))
+		if idx != i || dist > 1e-9 {
+			t.Errorf("Expected ANSI color %d to match itself, got %d (distance %f)", i, idx, dist)
+		}
+	}
+}
+
+func TestNearestApproximations(t *testing.T) {
+	tests := []struct {
+		name   string
+		color  RGB
+		xterm  int
+		ansi16 int
+	}{
+		{"termpicker pink", RGB{183, 65, 110}, 132, 1},
+		{"near black", RGB{10, 10, 12}, 232, 0},
+		{"near white", RGB{250, 250, 250}, 231, 15},
+		{"navy", RGB{0, 0, 128}, 18, 4},
+	}
+	for _, test := range tests {
+		if idx, _ := Nearest256(test.color); idx != test.xterm {
+			t.Errorf(AssertTemplate, test.name, test.color, test.xterm, idx)
+		}
+		if idx, _ := Nearest16(test.color); idx != test.ansi16 {
+			t.Errorf(AssertTemplate, test.name, test.color, test.ansi16, idx)
+		}
+	}
+}
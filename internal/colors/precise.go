@@ -6,8 +6,6 @@ import (
 	"strings"
 )
 
-const esc = "\\X1B"
-
 type ColorSpace interface {
 	ToPrecise() PreciseColor
 	FromPrecise(PreciseColor) ColorSpace
@@ -43,18 +41,8 @@ func Hex(cs ColorSpace) string {
 	))
 }
 
+// EscapedSeq returns the truecolor SGR sequence of a color using the
+// default escape options.
 func EscapedSeq(cs ColorSpace, fg bool) string {
-	p := cs.ToPrecise()
-	mod := 38 // fg by default
-	if !fg {
-		mod += 10
-	}
-
-	r := int(math.Round(p.R * 255))
-	g := int(math.Round(p.G * 255))
-	b := int(math.Round(p.B * 255))
-
-	return fmt.Sprintf("%s[%d;2;%d;%d;%dm",
-		esc, mod, r, g, b,
-	)
+	return EscapeOpts{}.Seq(cs, fg)
 }
@@ -0,0 +1,50 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Approximated reports whether colors have to be degraded to be shown by a
+// terminal with the given profile.
+func Approximated(p colorprofile.Profile) bool {
+	return p == colorprofile.ANSI256 || p == colorprofile.ANSI
+}
+
+// Degrade returns what a terminal with the given profile can show for cs:
+// the nearest color of its palette (see Nearest256 and Nearest16), without
+// dithering. Palette colors are returned as indices so the terminal uses its
+// own version of them. Other profiles get cs as is.
+func Degrade(p colorprofile.Profile, cs ColorSpace) color.Color {
+	switch p {
+	case colorprofile.ANSI256:
+		i, _ := Nearest256(cs)
+		return ansi.ExtendedColor(i)
+	case colorprofile.ANSI:
+		i, _ := Nearest16(cs)
+		return ansi.BasicColor(i)
+	default:
+		return ToColor(cs)
+	}
+}
+
+// ToColor converts cs for the standard library (and lipgloss)
+func ToColor(cs ColorSpace) color.Color {
+	p := cs.ToPrecise()
+	return color.RGBA{
+		R: uint8(math.Round(p.R * 255)),
+		G: uint8(math.Round(p.G * 255)),
+		B: uint8(math.Round(p.B * 255)),
+		A: 255,
+	}
+}
+
+// FromColor converts a color of the standard library (ex: reported by the
+// terminal)
+func FromColor(c color.Color) RGB {
+	r, g, b, _ := c.RGBA()
+	return RGB{R: int(r >> 8), G: int(g >> 8), B: int(b >> 8)}
+}
@@ -0,0 +1,36 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDegrade(t *testing.T) {
+	c := RGB{R: 95, G: 135, B: 175}
+	tests := []struct {
+		profile colorprofile.Profile
+		want    color.Color
+	}{
+		{colorprofile.TrueColor, color.RGBA{R: 95, G: 135, B: 175, A: 255}},
+		{colorprofile.ANSI256, ansi.ExtendedColor(67)},
+		{colorprofile.ANSI, ansi.BasicColor(8)},
+	}
+	for _, test := range tests {
+		if got := Degrade(test.profile, c); got != test.want {
+			t.Errorf("Degrade(%s, %s) = %v, want %v", test.profile, Hex(c), got, test.want)
+		}
+	}
+	if Approximated(colorprofile.TrueColor) || !Approximated(colorprofile.ANSI256) {
+		t.Error("only limited profiles should be approximated")
+	}
+}
+
+func TestColorRoundTrip(t *testing.T) {
+	c := RGB{R: 183, G: 65, B: 110}
+	if got := FromColor(ToColor(c)); got != c {
+		t.Errorf("FromColor(ToColor(%v)) = %v", c, got)
+	}
+}
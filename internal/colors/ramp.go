@@ -0,0 +1,60 @@
+package colors
+
+import "math"
+
+// RampSteps are the shade names of a ramp, from lightest to darkest. They
+// follow Tailwind's naming.
+var RampSteps = []int{50, 100, 200, 300, 400, 500, 600, 700, 800, 900, 950}
+
+// Lightness of the (unused) 0 and 1000 ends of a ramp
+const (
+	rampMaxL = 0.99
+	rampMinL = 0.22
+)
+
+// Shade is a ramp entry
+type Shade struct {
+	Step  int
+	Color OKLCH
+}
+
+// Ramp spreads cs into shades of even OKLCH lightness steps, keeping its hue.
+// The color itself becomes the shade whose default lightness is the closest
+// to its own, and the other shades are spaced evenly on each side of it.
+//
+// With easeChroma, shades get less colorful the further they are from cs,
+// which avoids garish tints and muddy shades. Either way, the chroma is
+// reduced when needed to stay within sRGB.
+func Ramp(cs ColorSpace, easeChroma bool) []Shade {
+	base := toOKLCH(cs)
+	defaultL := func(step int) float64 {
+		return rampMaxL - (rampMaxL-rampMinL)*float64(step)/1000
+	}
+
+	anchor := RampSteps[0]
+	for _, step := range RampSteps {
+		if math.Abs(defaultL(step)-base.L) < math.Abs(defaultL(anchor)-base.L) {
+			anchor = step
+		}
+	}
+
+	shades := make([]Shade, len(RampSteps))
+	for i, step := range RampSteps {
+		c := base
+		// Distance from the anchor, from 0 (the anchor) to 1 (the ends)
+		var d float64
+		switch {
+		case step < anchor:
+			d = float64(anchor-step) / float64(anchor)
+			c.L = base.L + (rampMaxL-base.L)*d
+		case step > anchor:
+			d = float64(step-anchor) / float64(1000-anchor)
+			c.L = base.L - (base.L-rampMinL)*d
+		}
+		if easeChroma {
+			c.C *= 1 - 0.6*d*d
+		}
+		shades[i] = Shade{Step: step, Color: c.MapToGamut()}
+	}
+	return shades
+}
@@ -0,0 +1,50 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRamp(t *testing.T) {
+	for _, base := range []ColorSpace{
+		RGB{183, 65, 110},
+		RGB{59, 130, 246},
+		RGB{250, 250, 250},
+		RGB{10, 10, 30},
+	} {
+		for _, ease := range []bool{false, true} {
+			shades := Ramp(base, ease)
+			if len(shades) != len(RampSteps) {
+				t.Fatalf("Expected %d shades, got %d", len(RampSteps), len(shades))
+			}
+			hasBase := false
+			for i, s := range shades {
+				if s.Step != RampSteps[i] {
+					t.Errorf("Expected shade %d to be %d, got %d", i, RampSteps[i], s.Step)
+				}
+				if !s.Color.InGamut() {
+					t.Errorf("Expected shade %d of %v to be displayable, got %v", s.Step, base, s.Color)
+				}
+				if i > 0 && s.Color.L >= shades[i-1].Color.L {
+					t.Errorf("Expected shade %d of %v to be darker than the previous one", s.Step, base)
+				}
+				hasBase = hasBase || Hex(s.Color) == Hex(base)
+			}
+			if !hasBase {
+				t.Errorf("Expected the ramp of %v to include it", base)
+			}
+		}
+	}
+
+	// Easing only ever lowers the chroma
+	plain := Ramp(RGB{59, 130, 246}, false)
+	eased := Ramp(RGB{59, 130, 246}, true)
+	for i := range plain {
+		if eased[i].Color.C > plain[i].Color.C+1e-6 {
+			t.Errorf("Expected eased shade %d to be less colorful", plain[i].Step)
+		}
+		if math.Abs(eased[i].Color.L-plain[i].Color.L) > 1e-9 {
+			t.Errorf("Expected easing to keep the lightness of shade %d", plain[i].Step)
+		}
+	}
+}
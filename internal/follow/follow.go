@@ -0,0 +1,41 @@
+// Package follow tracks the entry of a panel (design token, theme color,
+// base16 slot...) that follows the picked color once loaded in the pickers.
+package follow
+
+import "github.com/ChausseBenjamin/termpicker/internal/colors"
+
+// Editing is the entry following the picked color until another one is
+// loaded. Its zero value follows nothing.
+type Editing struct {
+	index  int
+	loaded bool
+	edited string // Hex code of the last color written to it
+}
+
+// Load makes entry i follow the picked color
+func (e *Editing) Load(i int) {
+	*e = Editing{index: i, loaded: true}
+}
+
+// Stop makes the entry stop following the picked color
+func (e *Editing) Stop() {
+	*e = Editing{}
+}
+
+// Is tells if entry i follows the picked color
+func (e Editing) Is(i int) bool {
+	return e.loaded && e.index == i
+}
+
+// Pick returns the entry the picked color c should be written to. It is
+// false when nothing follows the picked color or when c isn't an edit: the
+// first color is the one just loaded, only later ones are edits.
+func (e *Editing) Pick(c colors.ColorSpace) (int, bool) {
+	if !e.loaded {
+		return 0, false
+	}
+	hex := colors.Hex(c)
+	edit := e.edited != "" && hex != e.edited
+	e.edited = hex
+	return e.index, edit
+}
@@ -0,0 +1,33 @@
+package follow
+
+import (
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+)
+
+func TestPick(t *testing.T) {
+	var e Editing
+	if _, ok := e.Pick(colors.RGB{R: 255}); ok {
+		t.Error("Nothing loaded, yet a pick was an edit")
+	}
+
+	e.Load(3)
+	if !e.Is(3) || e.Is(0) {
+		t.Errorf("Expected only entry 3 to follow the picked color")
+	}
+	if _, ok := e.Pick(colors.RGB{R: 255}); ok {
+		t.Error("The loaded color itself was taken as an edit")
+	}
+	if _, ok := e.Pick(colors.RGB{R: 255}); ok {
+		t.Error("An unchanged color was taken as an edit")
+	}
+	if i, ok := e.Pick(colors.RGB{G: 255}); !ok || i != 3 {
+		t.Errorf("Expected an edit of entry 3, got %d (%v)", i, ok)
+	}
+
+	e.Load(5)
+	if _, ok := e.Pick(colors.RGB{B: 255}); ok {
+		t.Error("Loading another entry didn't start over")
+	}
+}
@@ -0,0 +1,156 @@
+package gradient
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const (
+	moveStep = 0.05 // How far H/L move a stop
+	minStops = 2
+)
+
+// Model edits a multi-stop gradient. Sending it a colors.ColorSpace changes
+// the picked color, which is what new stops are made of.
+type Model struct {
+	grad    colors.Gradient
+	active  int // Selected stop
+	picked  colors.ColorSpace
+	samples int // How many colors are copied by the samples key
+}
+
+// New starts a gradient going from the picked color to its complement
+func New(picked colors.ColorSpace, samples int) *Model {
+	complement := colors.Complementary.Generate(picked, colors.HarmonyOKLCH)[1]
+	return &Model{
+		grad: colors.Gradient{
+			Stops: []colors.Stop{{Color: picked, Pos: 0}, {Color: complement, Pos: 1}},
+			Space: colors.InterpOKLCH,
+		},
+		picked:  picked,
+		samples: samples,
+	}
+}
+
+// Selected is the color of the selected stop
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	return m.grad.Stops[m.active].Color, true
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.active = min(m.active+1, len(m.grad.Stops)-1)
+		case key.Matches(msg, keys.prev):
+			m.active = max(m.active-1, 0)
+		case key.Matches(msg, keys.right):
+			m.move(moveStep)
+		case key.Matches(msg, keys.left):
+			m.move(-moveStep)
+		case key.Matches(msg, keys.add):
+			m.add()
+		case key.Matches(msg, keys.del):
+			if len(m.grad.Stops) > minStops {
+				m.grad.Stops = slices.Delete(slices.Clone(m.grad.Stops), m.active, m.active+1)
+				m.active = min(m.active, len(m.grad.Stops)-1)
+			}
+		case key.Matches(msg, keys.put):
+			m.grad.Stops = slices.Clone(m.grad.Stops)
+			m.grad.Stops[m.active].Color = m.picked
+		case key.Matches(msg, keys.space):
+			all := colors.Interpolations()
+			m.grad.Space = all[(int(m.grad.Space)+1)%len(all)]
+		case key.Matches(msg, keys.hue):
+			all := colors.HueDirections()
+			m.grad.Hue = all[(int(m.grad.Hue)+1)%len(all)]
+		case key.Matches(msg, keys.css):
+			return m, util.SmartCopyToClipboard(m.grad.CSS())
+		case key.Matches(msg, keys.samples):
+			hexes := []string{}
+			for _, c := range m.grad.Sample(m.samples) {
+				hexes = append(hexes, colors.Hex(c))
+			}
+			return m, util.SmartCopyToClipboard(strings.Join(hexes, "\n"))
+		}
+	case colors.ColorSpace:
+		m.picked = msg
+	}
+	return m, nil
+}
+
+// move shifts the selected stop, swapping it with its neighbors so the
+// stops stay sorted and the selection follows it.
+func (m *Model) move(delta float64) {
+	stops := slices.Clone(m.grad.Stops)
+	i := m.active
+	stops[i].Pos = math.Max(0, math.Min(1, stops[i].Pos+delta))
+	for i > 0 && stops[i-1].Pos > stops[i].Pos {
+		stops[i-1], stops[i] = stops[i], stops[i-1]
+		i--
+	}
+	for i < len(stops)-1 && stops[i+1].Pos < stops[i].Pos {
+		stops[i+1], stops[i] = stops[i], stops[i+1]
+		i++
+	}
+	m.grad.Stops, m.active = stops, i
+}
+
+// add inserts the picked color halfway between the selected stop and the
+// next one (or the previous one for the last stop) and selects it.
+func (m *Model) add() {
+	i := m.active
+	if i == len(m.grad.Stops)-1 {
+		i--
+	}
+	pos := (m.grad.Stops[i].Pos + m.grad.Stops[i+1].Pos) / 2
+	m.grad.Stops = slices.Insert(slices.Clone(m.grad.Stops), i+1, colors.Stop{Color: m.picked, Pos: pos})
+	m.active = i + 1
+}
+
+func (m Model) View(width int) string {
+	var bar strings.Builder
+	for x := range width {
+		t := float64(x) / float64(max(1, width-1))
+		bar.WriteString(lg.NewStyle().
+			Background(lg.Color(colors.Hex(m.grad.At(t)))).
+			Render(" "))
+	}
+
+	// The selected stop is drawn last so it's never hidden by another one
+	markers := []rune(strings.Repeat(" ", width))
+	col := func(pos float64) int { return int(math.Round(pos * float64(width-1))) }
+	for _, s := range m.grad.Stops {
+		markers[col(s.Pos)] = '|'
+	}
+	markers[col(m.grad.Stops[m.active].Pos)] = []rune(ui.SwatchSelRune)[0]
+
+	method := m.grad.Space.String()
+	if m.grad.Space == colors.InterpOKLCH {
+		method += " " + m.grad.Hue.String() + " hue"
+	}
+	stop := m.grad.Stops[m.active]
+	info := fmt.Sprintf("stop %d/%d %s at %.0f%%  in %s",
+		m.active+1, len(m.grad.Stops), colors.Hex(stop.Color), stop.Pos*100, method)
+
+	return strings.Join([]string{
+		ui.Style().Readout.Render("Gradient"),
+		bar.String(),
+		bar.String(),
+		ui.Style().PickerCursor.Render(string(markers)),
+		ui.Style().Readout.Render(info),
+	}, "\n")
+}
@@ -0,0 +1,67 @@
+package gradient
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, right, left, add, del, put, space, hue, css, samples key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next stop"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev stop"),
+		),
+		right: key.NewBinding(
+			key.WithKeys("L", "shift+right"),
+			key.WithHelp("L", "move stop right"),
+		),
+		left: key.NewBinding(
+			key.WithKeys("H", "shift+left"),
+			key.WithHelp("H", "move stop left"),
+		),
+		add: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add picked color"),
+		),
+		del: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete stop"),
+		),
+		put: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "set stop to picked color"),
+		),
+		space: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "next interpolation space"),
+		),
+		hue: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "next hue direction"),
+		),
+		css: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy as CSS"),
+		),
+		samples: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy sampled colors"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.right, k.left, k.add, k.del, k.put, k.space, k.hue, k.css, k.samples}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
@@ -0,0 +1,83 @@
+package harmony
+
+import (
+	"fmt"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Model shows a color harmony built around the switcher's color. Sending
+// it a colors.ColorSpace changes the base color.
+type Model struct {
+	base    colors.ColorSpace
+	harmony colors.Harmony
+	space   colors.HarmonySpace
+	strip   swatches.Model
+}
+
+func New(base colors.ColorSpace) *Model {
+	m := &Model{
+		base:    base,
+		harmony: colors.Complementary,
+		space:   colors.HarmonyHSL,
+		strip:   *swatches.New(),
+	}
+	m.generate()
+	return m
+}
+
+func (m *Model) generate() {
+	scheme := m.harmony.Generate(m.base, m.space)
+	s := make([]swatches.Swatch, len(scheme))
+	for i, c := range scheme {
+		s[i] = swatches.Swatch{Color: c}
+	}
+	m.strip.SetSwatches(s)
+}
+
+// Selected is the swatch under the cursor
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	s, ok := m.strip.Selected()
+	return s.Color, ok
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	kinds := colors.Harmonies()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.strip.Next()
+		case key.Matches(msg, keys.prev):
+			m.strip.Prev()
+		case key.Matches(msg, keys.nextKind):
+			m.harmony = kinds[(int(m.harmony)+1)%len(kinds)]
+		case key.Matches(msg, keys.prevKind):
+			m.harmony = kinds[(int(m.harmony)+len(kinds)-1)%len(kinds)]
+		case key.Matches(msg, keys.space):
+			if m.space == colors.HarmonyHSL {
+				m.space = colors.HarmonyOKLCH
+			} else {
+				m.space = colors.HarmonyHSL
+			}
+		}
+		m.generate()
+	case colors.ColorSpace:
+		m.base = msg
+		m.generate()
+	}
+	return m, nil
+}
+
+func (m Model) View(width int) string {
+	m.strip.SetWidth(width)
+	title := ui.Style().Readout.Render(fmt.Sprintf("%s harmony (%s)", m.harmony, m.space))
+	return title + "\n" + m.strip.View()
+}
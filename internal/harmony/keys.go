@@ -0,0 +1,43 @@
+package harmony
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, nextKind, prevKind, space key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next swatch"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev swatch"),
+		),
+		nextKind: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "next harmony"),
+		),
+		prevKind: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "prev harmony"),
+		),
+		space: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "toggle HSL/OKLCH"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.nextKind, k.prevKind, k.space}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
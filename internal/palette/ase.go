@@ -0,0 +1,242 @@
+package palette
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf16"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+// Adobe Swatch Exchange files are big endian: a "ASEF" signature, a 1.0
+// version, a block count, then blocks made of a type, a length and a body.
+const aseSignature = "ASEF"
+
+const (
+	aseColor      uint16 = 0x0001
+	aseGroupStart uint16 = 0xc001
+	aseGroupEnd   uint16 = 0xc002
+)
+
+const aseNormal uint16 = 2 // Color type (0 global, 1 spot, 2 normal)
+
+var errASE = errors.New("invalid Adobe swatch exchange file")
+
+func decodeASE(data []byte) (string, []swatches.Swatch, error) {
+	r := bytes.NewReader(data)
+	var header struct {
+		Signature [4]byte
+		Major     uint16
+		Minor     uint16
+		Blocks    uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil ||
+		string(header.Signature[:]) != aseSignature {
+		return "", nil, fmt.Errorf("%w: missing %q signature", errASE, aseSignature)
+	}
+
+	var name string
+	list := []swatches.Swatch{}
+	for i := uint32(0); i < header.Blocks; i++ {
+		var block struct {
+			Type   uint16
+			Length uint32
+		}
+		if err := binary.Read(r, binary.BigEndian, &block); err != nil {
+			return "", nil, fmt.Errorf("%w: block %d: %w", errASE, i, err)
+		}
+		// The length comes from the file: check it before allocating
+		if block.Length > uint32(r.Len()) {
+			return "", nil, fmt.Errorf("%w: block %d: %w", errASE, i, io.ErrUnexpectedEOF)
+		}
+		body := make([]byte, block.Length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return "", nil, fmt.Errorf("%w: block %d: %w", errASE, i, err)
+		}
+
+		switch block.Type {
+		case aseGroupStart:
+			// Groups are flattened; the first one names the palette
+			if group, _, err := readASEName(bytes.NewReader(body)); err == nil && name == "" {
+				name = group
+			}
+		case aseColor:
+			s, err := readASEColor(bytes.NewReader(body))
+			if err != nil {
+				return "", nil, fmt.Errorf("%w: block %d: %w", errASE, i, err)
+			}
+			list = append(list, s)
+		}
+	}
+	return name, list, nil
+}
+
+func readASEName(r *bytes.Reader) (string, int, error) {
+	var n uint16 // UTF-16 code units, including the terminating NUL
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return "", 0, err
+	}
+	units := make([]uint16, n)
+	if err := binary.Read(r, binary.BigEndian, units); err != nil {
+		return "", 0, err
+	}
+	if n > 0 && units[n-1] == 0 {
+		units = units[:n-1]
+	}
+	return string(utf16.Decode(units)), int(n), nil
+}
+
+func readASEColor(r *bytes.Reader) (swatches.Swatch, error) {
+	name, _, err := readASEName(r)
+	if err != nil {
+		return swatches.Swatch{}, err
+	}
+	var model [4]byte
+	if err := binary.Read(r, binary.BigEndian, &model); err != nil {
+		return swatches.Swatch{}, err
+	}
+
+	var size int
+	switch string(model[:]) {
+	case "RGB ", "LAB ":
+		size = 3
+	case "CMYK":
+		size = 4
+	case "Gray":
+		size = 1
+	default:
+		return swatches.Swatch{}, fmt.Errorf("unknown color model %q", model[:])
+	}
+	v := make([]float32, size)
+	if err := binary.Read(r, binary.BigEndian, v); err != nil {
+		return swatches.Swatch{}, err
+	}
+
+	s := swatches.Swatch{Name: name}
+	switch string(model[:]) {
+	case "RGB ":
+		s.Color = colors.RGB{}.FromPrecise(colors.PreciseColor{
+			R: float64(v[0]), G: float64(v[1]), B: float64(v[2]),
+		})
+	case "CMYK":
+		s.Color = colors.CMYK{
+			C: int(math.Round(float64(v[0]) * 100)),
+			M: int(math.Round(float64(v[1]) * 100)),
+			Y: int(math.Round(float64(v[2]) * 100)),
+			K: int(math.Round(float64(v[3]) * 100)),
+		}
+		s.Notation = colors.NotationCMYK
+	case "LAB ":
+		// Lightness is stored as 0-1 and relative to D50
+		p := colors.LabD50ToPrecise(float64(v[0])*100, float64(v[1]), float64(v[2]))
+		s.Color = colors.Lab{}.FromPrecise(p)
+	case "Gray":
+		g := float64(v[0])
+		s.Color = colors.RGB{}.FromPrecise(colors.PreciseColor{R: g, G: g, B: g})
+	}
+	return s, nil
+}
+
+func encodeASE(name string, list []swatches.Swatch) ([]byte, error) {
+	var blocks bytes.Buffer
+	count := uint32(0)
+	block := func(kind uint16, body []byte) error {
+		if err := binary.Write(&blocks, binary.BigEndian, kind); err != nil {
+			return err
+		}
+		if err := binary.Write(&blocks, binary.BigEndian, uint32(len(body))); err != nil {
+			return err
+		}
+		blocks.Write(body)
+		count++
+		return nil
+	}
+
+	group, err := aseName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := block(aseGroupStart, group); err != nil {
+		return nil, err
+	}
+	for _, s := range list {
+		body, err := aseColorBody(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := block(aseColor, body); err != nil {
+			return nil, err
+		}
+	}
+	if err := block(aseGroupEnd, nil); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString(aseSignature)
+	if err := binary.Write(&out, binary.BigEndian, []uint16{1, 0}); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(&out, binary.BigEndian, count); err != nil {
+		return nil, err
+	}
+	out.Write(blocks.Bytes())
+	return out.Bytes(), nil
+}
+
+// aseColorBody encodes the body of a color block: its name, color model,
+// values and color type
+func aseColorBody(s swatches.Swatch) ([]byte, error) {
+	name, err := aseName(s.Name)
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	body.Write(name)
+
+	var values []float32
+	switch c := s.Color.(type) {
+	case colors.CMYK:
+		body.WriteString("CMYK")
+		values = []float32{
+			float32(c.C) / 100, float32(c.M) / 100,
+			float32(c.Y) / 100, float32(c.K) / 100,
+		}
+	case colors.Lab:
+		l, a, b := colors.PreciseToLabD50(c.ToPrecise())
+		body.WriteString("LAB ")
+		values = []float32{float32(l / 100), float32(a), float32(b)}
+	default:
+		p := s.Color.ToPrecise()
+		body.WriteString("RGB ")
+		values = []float32{float32(p.R), float32(p.G), float32(p.B)}
+	}
+	if err := binary.Write(&body, binary.BigEndian, values); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(&body, binary.BigEndian, aseNormal); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+// aseName encodes a length-prefixed, NUL terminated UTF-16 string
+func aseName(s string) ([]byte, error) {
+	units := append(utf16.Encode([]rune(s)), 0)
+	if len(units) > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: name too long (%d characters)", errASE, len(units))
+	}
+	var b bytes.Buffer
+	if err := binary.Write(&b, binary.BigEndian, uint16(len(units))); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(&b, binary.BigEndian, units); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
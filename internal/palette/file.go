@@ -0,0 +1,139 @@
+package palette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+const fileVersion = 1
+
+var (
+	errVersion = errors.New("unsupported palette file version")
+	errSwatch  = errors.New("invalid swatch")
+
+	// Saving a palette would rewrite the whole document, losing what isn't
+	// a color: tokens are only converted with the palette subcommand.
+	errTokensFile = errors.New("design tokens are edited with --tokens, not as a palette")
+)
+
+// file is the JSON representation of a palette (see description.txt for the
+// documented schema). Colors are always written as hex codes so diffs stay
+// readable, but any format the color input understands can be read.
+type file struct {
+	Version  int          `json:"version"`
+	Name     string       `json:"name"`
+	Swatches []fileSwatch `json:"swatches"`
+}
+
+type fileSwatch struct {
+	Name   string `json:"name"`
+	Color  string `json:"color"`
+	Notes  string `json:"notes,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// Decode reads a palette written by Encode
+func Decode(data []byte) (string, []swatches.Swatch, error) {
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", nil, err
+	}
+	if f.Version > fileVersion {
+		return "", nil, fmt.Errorf("%w: %d", errVersion, f.Version)
+	}
+
+	list := make([]swatches.Swatch, len(f.Swatches))
+	for i, fs := range f.Swatches {
+		c, err := parse.Color(fs.Color)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w %q: %w", errSwatch, fs.Name, err)
+		}
+		var notation colors.Notation
+		if fs.Format != "" {
+			if notation, err = colors.ParseNotation(fs.Format); err != nil {
+				return "", nil, fmt.Errorf("%w %q: %w", errSwatch, fs.Name, err)
+			}
+		}
+		list[i] = swatches.Swatch{Color: c, Name: fs.Name, Notes: fs.Notes, Notation: notation}
+	}
+	return f.Name, list, nil
+}
+
+// Encode writes a palette as indented JSON
+func Encode(name string, list []swatches.Swatch) ([]byte, error) {
+	f := file{Version: fileVersion, Name: name, Swatches: []fileSwatch{}}
+	for _, s := range list {
+		fs := fileSwatch{Name: s.Name, Color: colors.Hex(s.Color), Notes: s.Notes}
+		if s.Notation != colors.NotationHex {
+			fs.Format = s.Notation.String()
+		}
+		f.Swatches = append(f.Swatches, fs)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	return append(data, '\n'), err
+}
+
+// Open loads the palette at path, in the format its extension names. A
+// missing file is created with an empty palette named after it.
+func (m *Model) Open(path string) error {
+	if FormatFromPath(path) == FormatTokens {
+		return fmt.Errorf("%s: %w", path, errTokensFile)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		m.path = path
+		m.name = NameFromPath(path)
+		m.strip.SetSwatches(nil)
+		return m.Save()
+	}
+	if err != nil {
+		return err
+	}
+
+	name, list, err := Import(data, FormatFromPath(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if name == "" {
+		name = NameFromPath(path)
+	}
+	m.path, m.name = path, name
+	m.strip.SetSwatches(list)
+	m.strip.Sel(0)
+	return nil
+}
+
+// NameFromPath names palettes after their file, for formats which don't
+// store a name.
+func NameFromPath(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// Save writes the palette to the file it was opened from, in the format its
+// extension names.
+func (m Model) Save() error {
+	data, err := Export(FormatFromPath(m.path), m.name, m.strip.Swatches())
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(m.path, data)
+}
+
+// SaveAs changes the file the palette is saved to, then saves it
+func (m *Model) SaveAs(path string) error {
+	if FormatFromPath(path) == FormatTokens {
+		return fmt.Errorf("%s: %w", path, errTokensFile)
+	}
+	m.path = path
+	return m.Save()
+}
@@ -0,0 +1,101 @@
+package palette
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	list := []swatches.Swatch{
+		{Color: colors.RGB{R: 183, G: 65, B: 110}, Name: "accent", Notes: "Buttons and links", Notation: colors.NotationOKLCH},
+		{Color: colors.RGB{R: 17, G: 26, B: 31}, Name: "background"},
+	}
+	data, err := Encode("brand", list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, got, err := Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "brand" || len(got) != len(list) {
+		t.Fatalf("Expected palette %q with %d swatches, got %q with %d", "brand", len(list), name, len(got))
+	}
+	for i := range list {
+		if colors.Hex(got[i].Color) != colors.Hex(list[i].Color) ||
+			got[i].Name != list[i].Name ||
+			got[i].Notes != list[i].Notes ||
+			got[i].Notation != list[i].Notation {
+			t.Errorf("Expected swatch %d to be %+v, got %+v", i, list[i], got[i])
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"version": 2, "name": "future", "swatches": []}`,
+		`{"version": 1, "name": "bad", "swatches": [{"name": "x", "color": "nope"}]}`,
+		`{"version": 1, "name": "bad", "swatches": [{"name": "x", "color": "#000000", "format": "nope"}]}`,
+		`not json`,
+	} {
+		if _, _, err := Decode([]byte(data)); err == nil {
+			t.Errorf("Expected an error decoding %s", data)
+		}
+	}
+
+	// Any color the input understands can be written by hand
+	_, list, err := Decode([]byte(`{"swatches": [{"name": "x", "color": "rgb(1, 2, 3)"}]}`))
+	if err != nil || colors.Hex(list[0].Color) != "#010203" {
+		t.Errorf("Expected rgb(1, 2, 3) to be read, got %v (%v)", list, err)
+	}
+}
+
+func TestOpenSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.json")
+
+	m := New()
+	if err := m.Open(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected a missing palette to be created: %v", err)
+	}
+	if m.Name() != "team" {
+		t.Errorf("Expected a new palette to be named after its file, got %q", m.Name())
+	}
+
+	m.strip.Insert(swatches.Swatch{Color: colors.RGB{R: 1, G: 2, B: 3}, Name: "one"})
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary file to be left behind, got %d files", len(entries))
+	}
+
+	reopened := New()
+	if err := reopened.Open(path); err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 1 || reopened.Swatches()[0].Name != "one" {
+		t.Errorf("Expected the saved swatch to be loaded back, got %+v", reopened.Swatches())
+	}
+}
+
+func TestTokensNotPalettes(t *testing.T) {
+	dir := t.TempDir()
+	m := New()
+	if err := m.Open(filepath.Join(dir, "brand.tokens.json")); err == nil {
+		t.Error("Expected design tokens to be refused as a palette")
+	}
+	if err := m.SaveAs(filepath.Join(dir, "brand.tokens")); err == nil {
+		t.Error("Expected saving a palette as design tokens to be refused")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no file to be written, got %d", len(entries))
+	}
+}
@@ -0,0 +1,92 @@
+package palette
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+	"github.com/ChausseBenjamin/termpicker/internal/tokens"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+var errUnknownFormat = errors.New("unrecognized palette format")
+
+// Format is a palette file format
+type Format int
+
+const (
+	FormatJSON     Format = iota // Termpicker's own (see file.go)
+	FormatGPL                    // GIMP, Krita and Inkscape
+	FormatASE                    // Adobe Swatch Exchange
+	FormatPaintNET               // Paint.NET
+	FormatTokens                 // W3C design tokens (DTCG)
+)
+
+// The first alias of each format is also its file extension
+var formats = [][]string{
+	FormatJSON:     {"json", "termpicker"},
+	FormatGPL:      {"gpl", "gimp", "krita", "inkscape"},
+	FormatASE:      {"ase", "adobe"},
+	FormatPaintNET: {"txt", "paint.net", "paintnet"},
+	FormatTokens:   {"tokens", "dtcg", "design-tokens"},
+}
+
+func (f Format) String() string { return formats[f][0] }
+
+// FormatNames lists the accepted names of every format
+func FormatNames() []string {
+	return util.Aliases(formats)
+}
+
+func ParseFormat(s string) (Format, error) {
+	i, err := util.LookupAlias(formats, s, errUnknownFormat)
+	return Format(i), err
+}
+
+// FormatFromPath guesses a file's format from its extension, defaulting to
+// termpicker's own.
+func FormatFromPath(path string) Format {
+	if strings.HasSuffix(strings.ToLower(path), ".tokens.json") {
+		return FormatTokens
+	}
+	if f, err := ParseFormat(strings.TrimPrefix(filepath.Ext(path), ".")); err == nil {
+		return f
+	}
+	return FormatJSON
+}
+
+// Import reads a palette in the given format. The name is empty when the
+// format doesn't store one.
+func Import(data []byte, f Format) (string, []swatches.Swatch, error) {
+	switch f {
+	case FormatGPL:
+		return decodeGPL(data)
+	case FormatASE:
+		return decodeASE(data)
+	case FormatPaintNET:
+		return decodePaintNET(data)
+	case FormatTokens:
+		list, err := tokens.Decode(data)
+		return "", list, err
+	default:
+		return Decode(data)
+	}
+}
+
+// Export writes a palette in the given format. Other formats than
+// termpicker's own lose the notes and preferred formats.
+func Export(f Format, name string, list []swatches.Swatch) ([]byte, error) {
+	switch f {
+	case FormatGPL:
+		return encodeGPL(name, list), nil
+	case FormatASE:
+		return encodeASE(name, list)
+	case FormatPaintNET:
+		return encodePaintNET(list)
+	case FormatTokens:
+		return tokens.Encode(list), nil
+	default:
+		return Encode(name, list)
+	}
+}
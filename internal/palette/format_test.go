@@ -0,0 +1,139 @@
+package palette
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	for path, want := range map[string]Format{
+		"brand.json":        FormatJSON,
+		"brand.gpl":         FormatGPL,
+		"Brand.ASE":         FormatASE,
+		"brand.txt":         FormatPaintNET,
+		"brand.tokens":      FormatTokens,
+		"brand.tokens.json": FormatTokens,
+		"brand":             FormatJSON,
+		"brand.color":       FormatJSON,
+	} {
+		if got := FormatFromPath(path); got != want {
+			t.Errorf("Expected %q to be %v, got %v", path, want, got)
+		}
+	}
+}
+
+func TestRoundTrips(t *testing.T) {
+	list := []swatches.Swatch{
+		{Color: colors.RGB{R: 183, G: 65, B: 110}, Name: "accent"},
+		{Color: colors.RGB{R: 17, G: 26, B: 31}, Name: "dark background"},
+	}
+	for _, f := range []Format{FormatJSON, FormatGPL, FormatASE, FormatPaintNET, FormatTokens} {
+		data, err := Export(f, "brand", list)
+		if err != nil {
+			t.Fatalf("%v: %v", f, err)
+		}
+		name, got, err := Import(data, f)
+		if err != nil {
+			t.Fatalf("%v: %v", f, err)
+		}
+		if f != FormatPaintNET && f != FormatTokens && name != "brand" {
+			t.Errorf("%v: Expected the palette name to survive, got %q", f, name)
+		}
+		if len(got) != len(list) {
+			t.Fatalf("%v: Expected %d swatches, got %d", f, len(list), len(got))
+		}
+		for i := range list {
+			if colors.Hex(got[i].Color) != colors.Hex(list[i].Color) {
+				t.Errorf("%v: Expected swatch %d to be %s, got %s", f, i, colors.Hex(list[i].Color), colors.Hex(got[i].Color))
+			}
+			if f != FormatPaintNET && got[i].Name != list[i].Name {
+				t.Errorf("%v: Expected swatch %d to be named %q, got %q", f, i, list[i].Name, got[i].Name)
+			}
+		}
+	}
+}
+
+func TestASEModels(t *testing.T) {
+	lab := colors.Lab{}.FromPrecise(colors.RGB{R: 40, G: 120, B: 200}.ToPrecise()).(colors.Lab)
+	list := []swatches.Swatch{
+		{Color: colors.CMYK{C: 10, M: 80, Y: 0, K: 5}, Name: "print"},
+		{Color: lab, Name: "measured"},
+	}
+	data, err := Export(FormatASE, "models", list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "CMYK") || !strings.Contains(string(data), "LAB ") {
+		t.Errorf("Expected CMYK and Lab swatches to keep their color model")
+	}
+	_, got, err := Import(data, FormatASE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := got[0].Color.(colors.CMYK); !ok || c != list[0].Color {
+		t.Errorf("Expected %v, got %v", list[0].Color, got[0].Color)
+	}
+	if got[0].Notation != colors.NotationCMYK {
+		t.Errorf("Expected CMYK swatches to be written in CMYK, got %v", got[0].Notation)
+	}
+	// Going through XYZ twice (D65 then D50) costs a little precision
+	l, ok := got[1].Color.(colors.Lab)
+	if !ok || math.Abs(l.L-lab.L) > 0.1 || math.Abs(l.A-lab.A) > 0.1 || math.Abs(l.B-lab.B) > 0.1 {
+		t.Errorf("Expected %v, got %v", lab, got[1].Color)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	for f, data := range map[Format]string{
+		FormatGPL:      "Not a palette\n",
+		FormatASE:      "ASE",
+		FormatPaintNET: "; paint.net Palette File\nFF00GG00\n",
+	} {
+		if _, _, err := Import([]byte(data), f); err == nil {
+			t.Errorf("%v: Expected an error importing %q", f, data)
+		}
+	}
+	if _, _, err := Import([]byte("GIMP Palette\n1 2\n"), FormatGPL); err == nil {
+		t.Errorf("Expected an error for a short GIMP palette line")
+	}
+
+	// GIMP palettes written by other tools
+	name, list, err := Import([]byte("GIMP Palette\nName: Krita\nColumns: 8\n#\n255   0   0 Red\n  0 0 255\n"), FormatGPL)
+	if err != nil || name != "Krita" || len(list) != 2 || list[0].Name != "Red" || colors.Hex(list[1].Color) != "#0000FF" {
+		t.Errorf("Expected a GIMP palette to be read, got %q %+v (%v)", name, list, err)
+	}
+
+	if _, _, err := Import([]byte("Groceries\neggs\n"), FormatPaintNET); err == nil {
+		t.Errorf("Expected an error for a .txt file which isn't a Paint.NET palette")
+	}
+
+	// Paint.NET palettes written by other tools (ex: Lospec)
+	for _, data := range []string{
+		";paint.net Palette File\nFFFF0000\nFF0000FF\n",
+		"\ufeff; paint.net Palette File\r\nFFFF0000\r\nFF0000FF\r\n",
+		"FFFF0000\nFF0000FF\n",
+	} {
+		_, list, err := Import([]byte(data), FormatPaintNET)
+		if err != nil || len(list) != 2 || colors.Hex(list[1].Color) != "#0000FF" {
+			t.Errorf("Expected %q to be read, got %+v (%v)", data, list, err)
+		}
+	}
+
+	// A block claiming more bytes than the file holds
+	huge := "ASEF\x00\x01\x00\x00\x00\x00\x00\x01\x00\x01\xff\xff\xff\xff"
+	if _, _, err := Import([]byte(huge), FormatASE); err == nil {
+		t.Errorf("Expected an error for an ASE block longer than the file")
+	}
+
+	big := make([]swatches.Swatch, paintNETMax+1)
+	for i := range big {
+		big[i].Color = colors.RGB{}
+	}
+	if _, err := Export(FormatPaintNET, "big", big); err == nil {
+		t.Errorf("Expected an error exporting over %d colors to Paint.NET", paintNETMax)
+	}
+}
@@ -0,0 +1,77 @@
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+const gplHeader = "GIMP Palette"
+
+var errGPL = errors.New("invalid GIMP palette")
+
+// decodeGPL reads GIMP palettes, which Krita and Inkscape also use:
+//
+//	GIMP Palette
+//	Name: brand
+//	Columns: 4
+//	# Comment
+//	183  65 110	accent
+func decodeGPL(data []byte) (string, []swatches.Swatch, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != gplHeader {
+		return "", nil, fmt.Errorf("%w: missing %q header", errGPL, gplHeader)
+	}
+
+	var name string
+	list := []swatches.Swatch{}
+	for line := 2; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if v, found := strings.CutPrefix(text, "Name:"); found {
+			name = strings.TrimSpace(v)
+			continue
+		}
+		if strings.HasPrefix(text, "Columns:") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return "", nil, fmt.Errorf("%w: line %d: %q", errGPL, line, text)
+		}
+		var rgb [3]int
+		for i := range rgb {
+			v, err := strconv.Atoi(fields[i])
+			if err != nil || v < 0 || v > 255 {
+				return "", nil, fmt.Errorf("%w: line %d: %q", errGPL, line, text)
+			}
+			rgb[i] = v
+		}
+		list = append(list, swatches.Swatch{
+			Color: colors.RGB{R: rgb[0], G: rgb[1], B: rgb[2]},
+			Name:  strings.Join(fields[3:], " "),
+		})
+	}
+	return name, list, scanner.Err()
+}
+
+func encodeGPL(name string, list []swatches.Swatch) []byte {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, gplHeader)
+	fmt.Fprintf(&b, "Name: %s\n", name)
+	fmt.Fprintln(&b, "#")
+	for _, s := range list {
+		c := colors.RGB{}.FromPrecise(s.Color.ToPrecise()).(colors.RGB)
+		fmt.Fprintf(&b, "%3d %3d %3d\t%s\n", c.R, c.G, c.B, s.Name)
+	}
+	return b.Bytes()
+}
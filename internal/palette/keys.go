@@ -0,0 +1,55 @@
+package palette
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, right, left, add, del, put, notation key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next swatch"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev swatch"),
+		),
+		right: key.NewBinding(
+			key.WithKeys("L", "shift+right"),
+			key.WithHelp("L", "move swatch right"),
+		),
+		left: key.NewBinding(
+			key.WithKeys("H", "shift+left"),
+			key.WithHelp("H", "move swatch left"),
+		),
+		add: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add picked color"),
+		),
+		del: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete swatch"),
+		),
+		put: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "set swatch to picked color"),
+		),
+		notation: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "next preferred format"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.right, k.left, k.add, k.del, k.put, k.notation}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
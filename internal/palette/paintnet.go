@@ -0,0 +1,63 @@
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+const (
+	paintNETMax    = 96 // Paint.NET ignores colors past this
+	paintNETHeader = "; paint.net Palette File"
+)
+
+var (
+	errPaintNET     = errors.New("invalid Paint.NET palette")
+	errPaintNETSize = fmt.Errorf("Paint.NET palettes hold at most %d colors", paintNETMax)
+)
+
+// decodePaintNET reads Paint.NET palettes: one AARRGGBB hex code per line,
+// with comments starting with a semicolon (the usual header being one).
+// Colors have no names and the alpha channel is ignored. Any other line
+// means the .txt file isn't a palette.
+func decodePaintNET(data []byte) (string, []swatches.Swatch, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff")) // UTF-8 BOM
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	list := []swatches.Swatch{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+		argb, err := strconv.ParseUint(text, 16, 32)
+		if err != nil || len(text) != 8 {
+			return "", nil, fmt.Errorf("%w: line %d: %q", errPaintNET, line, text)
+		}
+		list = append(list, swatches.Swatch{Color: colors.RGB{
+			R: int(argb >> 16 & 0xff),
+			G: int(argb >> 8 & 0xff),
+			B: int(argb & 0xff),
+		}})
+	}
+	return "", list, scanner.Err()
+}
+
+func encodePaintNET(list []swatches.Swatch) ([]byte, error) {
+	if len(list) > paintNETMax {
+		return nil, fmt.Errorf("%w (got %d)", errPaintNETSize, len(list))
+	}
+	var b bytes.Buffer
+	fmt.Fprintln(&b, paintNETHeader)
+	fmt.Fprintln(&b, "; Lines that start with a semicolon are comments")
+	for _, s := range list {
+		c := colors.RGB{}.FromPrecise(s.Color.ToPrecise()).(colors.RGB)
+		fmt.Fprintf(&b, "FF%02X%02X%02X\n", c.R, c.G, c.B)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,148 @@
+package palette
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const defaultName = "untitled"
+
+// Model is a named list of swatches the user builds while picking colors.
+// Sending it a colors.ColorSpace changes the picked color, which is what
+// gets added to the palette.
+type Model struct {
+	name   string
+	path   string // File the palette is saved to (empty until saved/opened)
+	strip  swatches.Model
+	picked colors.ColorSpace
+}
+
+func New() *Model {
+	return &Model{
+		name:  defaultName,
+		strip: *swatches.New(),
+	}
+}
+
+func (m Model) Name() string { return m.name }
+
+func (m Model) Path() string { return m.path }
+
+func (m Model) Swatches() []swatches.Swatch { return m.strip.Swatches() }
+
+func (m Model) Len() int { return m.strip.Len() }
+
+// Rename names the selected swatch
+func (m *Model) Rename(name string) {
+	if s, ok := m.strip.Selected(); ok {
+		s.Name = name
+		m.strip.Set(s)
+	}
+}
+
+// SetNotes changes the notes of the selected swatch
+func (m *Model) SetNotes(notes string) {
+	if s, ok := m.strip.Selected(); ok {
+		s.Notes = notes
+		m.strip.Set(s)
+	}
+}
+
+// Selected is the color of the selected swatch
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	s, ok := m.strip.Selected()
+	return s.Color, ok
+}
+
+// SelectedSwatch returns the selected swatch along with its name, notes
+// and preferred notation.
+func (m Model) SelectedSwatch() (swatches.Swatch, bool) {
+	return m.strip.Selected()
+}
+
+// Click selects the swatch at x, y of the palette drawn width columns wide
+// (see View)
+func (m *Model) Click(x, y, width int) {
+	m.strip.SetWidth(width)
+	// Swatches take the two rows below the title
+	if y != 1 && y != 2 {
+		return
+	}
+	if i, ok := m.strip.At(x); ok {
+		m.strip.Sel(i)
+	}
+}
+
+// nextName returns the first "color-N" name that isn't taken
+func (m Model) nextName() string {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("color-%d", i)
+		if !slices.ContainsFunc(m.strip.Swatches(), func(s swatches.Swatch) bool { return s.Name == name }) {
+			return name
+		}
+	}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.strip.Next()
+		case key.Matches(msg, keys.prev):
+			m.strip.Prev()
+		case key.Matches(msg, keys.right):
+			m.strip.Move(1)
+		case key.Matches(msg, keys.left):
+			m.strip.Move(-1)
+		case key.Matches(msg, keys.add):
+			if m.picked != nil {
+				m.strip.Insert(swatches.Swatch{Color: m.picked, Name: m.nextName()})
+			}
+		case key.Matches(msg, keys.del):
+			m.strip.Delete()
+		case key.Matches(msg, keys.notation):
+			if s, ok := m.strip.Selected(); ok {
+				all := colors.Notations()
+				s.Notation = all[(int(s.Notation)+1)%len(all)]
+				m.strip.Set(s)
+			}
+		case key.Matches(msg, keys.put):
+			if s, ok := m.strip.Selected(); ok && m.picked != nil {
+				s.Color = m.picked
+				m.strip.Set(s)
+			}
+		}
+	case colors.ColorSpace:
+		m.picked = msg
+	}
+	return m, nil
+}
+
+func (m Model) View(width int) string {
+	m.strip.SetWidth(width)
+	count := fmt.Sprintf("%d swatches", m.strip.Len())
+	if m.strip.Len() == 1 {
+		count = "1 swatch"
+	}
+	title := ui.Style().Readout.Render(fmt.Sprintf("Palette %q (%s)", m.name, count))
+	if m.strip.Len() == 0 {
+		return title + "\n" + ui.Style().Readout.Render("Press a to add the picked color")
+	}
+	view := title + "\n" + m.strip.View()
+	if s, _ := m.strip.Selected(); s.Notes != "" {
+		notes := lg.NewStyle().MaxWidth(width).Render(s.Notes)
+		view += "\n" + ui.Style().Readout.Faint(true).Render(notes)
+	}
+	return view
+}
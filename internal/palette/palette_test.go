@@ -0,0 +1,26 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+func TestClick(t *testing.T) {
+	m := New()
+	m.strip.SetSwatches([]swatches.Swatch{
+		{Color: colors.RGB{R: 255}},
+		{Color: colors.RGB{G: 255}},
+		{Color: colors.RGB{B: 255}},
+	})
+
+	m.Click(25, 1, 60) // Second of three 20 columns wide swatches
+	if m.strip.Active() != 1 {
+		t.Errorf("Expected the clicked swatch to be selected, got %d", m.strip.Active())
+	}
+	m.Click(50, 0, 60) // Title
+	if m.strip.Active() != 1 {
+		t.Errorf("Expected clicks outside of the swatches to be ignored, got %d", m.strip.Active())
+	}
+}
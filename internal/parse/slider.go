@@ -0,0 +1,90 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	errSliderParsing = errors.New("not a number or expression")
+	errSliderRange   = errors.New("out of range")
+	errDivByZero     = errors.New("division by zero")
+	errStepParsing   = errors.New("a step must be a positive number, optionally followed by %")
+)
+
+// SliderValue evaluates what was typed in a slider's field. It is either a
+// value ("183") or an operation on the current one ("+12", "-5", "*0.8",
+// "/2"). A "%" suffix counts in percents of the slider's range (so "50%" is
+// half of max and "*80%" is "*0.8") while degree suffixes ("°", "deg") are
+// accepted and ignored. Numbers are in the color component's units, scale
+// being what one unit of the slider is worth in them. The result is rounded
+// and must fit in 0-max.
+func SliderValue(s string, current, max int, scale float64) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "°"), "deg")
+	pcnt := strings.HasSuffix(s, "%")
+	s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+
+	var op byte
+	if s != "" && strings.ContainsRune("+-*/x", rune(s[0])) {
+		op, s = s[0], strings.TrimSpace(s[1:])
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, errSliderParsing
+	}
+
+	cur := float64(current)
+	switch op {
+	case '*', 'x', '/':
+		if pcnt {
+			n /= 100
+		}
+		if op == '/' {
+			if n == 0 {
+				return 0, errDivByZero
+			}
+			n = 1 / n
+		}
+		cur *= n
+	default:
+		if pcnt {
+			n *= float64(max) / 100
+		} else {
+			n /= scale
+		}
+		switch op {
+		case '+':
+			cur += n
+		case '-':
+			cur -= n
+		default:
+			cur = n
+		}
+	}
+
+	v := int(math.Round(cur))
+	if v < 0 || v > max {
+		return 0, fmt.Errorf("%w (0-%.4g)", errSliderRange, float64(max)*scale)
+	}
+	return v, nil
+}
+
+// Step parses a slider step: a positive amount in the units of the color
+// component ("0.01") or, with a "%" suffix, a percentage of the slider's
+// range ("5%" gives 0.05 and pcnt).
+func Step(s string) (v float64, pcnt bool, err error) {
+	s = strings.TrimSpace(s)
+	pcnt = strings.HasSuffix(s, "%")
+	v, err = strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+	if err != nil || !(v > 0) || math.IsInf(v, 0) {
+		return 0, false, fmt.Errorf("%w: %q", errStepParsing, s)
+	}
+	if pcnt {
+		v /= 100
+	}
+	return v, pcnt, nil
+}
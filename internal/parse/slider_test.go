@@ -0,0 +1,98 @@
+package parse
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSliderValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		current int
+		max     int
+		want    int
+		err     error
+	}{
+		{"value", "183", 127, 255, 183, nil},
+		{"spaces", "  42 ", 127, 255, 42, nil},
+		{"add", "+12", 127, 255, 139, nil},
+		{"subtract", "-27", 127, 255, 100, nil},
+		{"multiply", "*0.8", 100, 255, 80, nil},
+		{"multiply with x", "x2", 100, 255, 200, nil},
+		{"divide", "/2", 255, 255, 128, nil},
+		{"percent of range", "50%", 0, 1000, 500, nil},
+		{"add percent", "+10%", 100, 255, 126, nil},
+		{"multiply percent", "*80%", 100, 255, 80, nil},
+		{"degrees", "180°", 0, 360, 180, nil},
+		{"deg", "90deg", 0, 360, 90, nil},
+		{"decimal rounds", "12.6", 0, 100, 13, nil},
+		{"max", "255", 0, 255, 255, nil},
+		{"above max", "256", 0, 255, 0, errSliderRange},
+		{"below zero", "-200", 127, 255, 0, errSliderRange},
+		{"division by zero", "/0", 127, 255, 0, errDivByZero},
+		{"empty", "", 127, 255, 0, errSliderParsing},
+		{"operator only", "+", 127, 255, 0, errSliderParsing},
+		{"garbage", "abc", 127, 255, 0, errSliderParsing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SliderValue(tt.input, tt.current, tt.max, 1)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SliderValue(%q) error = %v, want %v", tt.input, err, tt.err)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("SliderValue(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliderValueScaled(t *testing.T) {
+	// An OKLCH chroma slider: 0-0.5 in thousandths
+	for input, want := range map[string]int{
+		"0.15":   150,
+		"+0.01":  160,
+		"-0.005": 145,
+		"*2":     300,
+		"50%":    250,
+	} {
+		if got, err := SliderValue(input, 150, 500, 0.001); err != nil || got != want {
+			t.Errorf("SliderValue(%q) = %d (%v), want %d", input, got, err, want)
+		}
+	}
+	if _, err := SliderValue("150", 150, 500, 0.001); !errors.Is(err, errSliderRange) {
+		t.Errorf("Expected slider units to be out of range, got %v", err)
+	}
+}
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+		pcnt  bool
+		err   error
+	}{
+		{"1", 1, false, nil},
+		{"0.001", 0.001, false, nil},
+		{" 15 ", 15, false, nil},
+		{"5%", 0.05, true, nil},
+		{"2.5 %", 0.025, true, nil},
+		{"0", 0, false, errStepParsing},
+		{"-1", 0, false, errStepParsing},
+		{"%", 0, false, errStepParsing},
+		{"fast", 0, false, errStepParsing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, pcnt, err := Step(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Step(%q) error = %v, want %v", tt.input, err, tt.err)
+			}
+			if err == nil && (math.Abs(got-tt.want) > 1e-9 || pcnt != tt.pcnt) {
+				t.Errorf("Step(%q) = %g, %t, want %g, %t", tt.input, got, pcnt, tt.want, tt.pcnt)
+			}
+		})
+	}
+}
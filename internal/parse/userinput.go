@@ -17,6 +17,7 @@ var (
 	errHSLParsing         = errors.New("failed to parse HSL color")
 	errCMYKParsing        = errors.New("failed to parse CMYK color")
 	errOKLCHParsing       = errors.New("failed to parse OKLCH color")
+	errXColorParsing      = errors.New("failed to parse X11 color")
 )
 
 func sanitize(s string) string {
@@ -36,6 +37,8 @@ func Color(s string) (colors.ColorSpace, error) {
 	switch {
 	case strings.Contains(s, "#"):
 		return hex(s)
+	case strings.HasPrefix(s, "rgb:"):
+		return xcolor(s)
 	case strings.Contains(s, "rgb"):
 		return rgb(s)
 	case strings.Contains(s, "hsl"):
@@ -65,6 +68,24 @@ func hex(s string) (colors.ColorSpace, error) {
 	return colors.RGB{R: r, G: g, B: b}, nil
 }
 
+// xcolor reads the X11 "rgb:r/g/b" form terminals use to report their colors.
+// Each channel has 1 to 4 hex digits.
+func xcolor(s string) (colors.ColorSpace, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "rgb:"), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: %q", errXColorParsing, s)
+	}
+	var c [3]int
+	for i, p := range parts {
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil || len(p) == 0 || len(p) > 4 {
+			return nil, fmt.Errorf("%w: %q", errXColorParsing, s)
+		}
+		c[i] = int(math.Round(float64(v) / float64(uint(1)<<(4*len(p))-1) * 255))
+	}
+	return colors.RGB{R: c[0], G: c[1], B: c[2]}, nil
+}
+
 func cmyk(s string) (colors.ColorSpace, error) {
 	var c, m, y, k int
 	_, err := fmt.Sscanf(s, "cmyk(%d,%d,%d,%d)", &c, &m, &y, &k)
@@ -27,6 +27,11 @@ func TestColorParsing(t *testing.T) {
 		{"rgb black", "rgb(0,0,0)", colors.RGB{R: 0, G: 0, B: 0}, false},
 		{"rgb white", "rgb(255,255,255)", colors.RGB{R: 255, G: 255, B: 255}, false},
 
+		// X11 formats reported by terminals
+		{"xcolor 4 digits", "rgb:ffff/8080/0000", colors.RGB{R: 255, G: 128, B: 0}, false},
+		{"xcolor 2 digits", "rgb:1e/1e/2e", colors.RGB{R: 30, G: 30, B: 46}, false},
+		{"xcolor 1 digit", "rgb:f/8/0", colors.RGB{R: 255, G: 136, B: 0}, false},
+
 		// HSL formats
 		{"hsl red", "hsl(0,100,50)", colors.HSL{H: 0, S: 100, L: 50}, false},
 		{"hsl green", "hsl(120,100,50)", colors.HSL{H: 120, S: 100, L: 50}, false},
@@ -63,6 +68,8 @@ func TestColorParsing(t *testing.T) {
 		{"malformed hsl", "hsl(abc,def,ghi)", nil, true},
 		{"malformed cmyk", "cmyk(abc,def,ghi,jkl)", nil, true},
 		{"malformed oklch", "oklch(abc def ghi)", nil, true},
+		{"malformed xcolor", "rgb:ff/ff", nil, true},
+		{"xcolor too precise", "rgb:fffff/0/0", nil, true},
 	}
 
 	for _, test := range tests {
@@ -34,10 +34,14 @@ func HSL() *Model {
 }
 
 func OKLCH() *Model {
+	c := slider.New('C', 500, ui.Style().Sliders.OC...)  // 0-0.5 scaled to 0-500
+	l := slider.New('L', 1000, ui.Style().Sliders.OL...) // 0-1 scaled to 0-1000
+	c.SetScale(0.001)
+	l.SetScale(0.001)
 	return New(
 		[]slider.Model{
-			slider.New('H', 360, ui.Style().Sliders.OH...),  // 0-360 as-is
-			slider.New('C', 500, ui.Style().Sliders.OC...),  // 0-0.5 scaled to 0-500
-			slider.New('L', 1000, ui.Style().Sliders.OL...), // 0-1 scaled to 0-1000
+			slider.New('H', 360, ui.Style().Sliders.OH...), // 0-360 as-is
+			c,
+			l,
 		}, "OKLCH")
 }
@@ -5,7 +5,8 @@ import (
 )
 
 type keybinds struct {
-	next, prev key.Binding
+	next, prev  key.Binding
+	first, last key.Binding
 }
 
 func newKeybinds() keybinds {
@@ -18,12 +19,20 @@ func newKeybinds() keybinds {
 			key.WithKeys("k", "up"),
 			key.WithHelp("k", "next slider"),
 		),
+		first: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("gg", "first slider"),
+		),
+		last: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "last slider"),
+		),
 	}
 }
 
 func Keys() []key.Binding {
 	k := newKeybinds()
-	return []key.Binding{k.next, k.prev}
+	return []key.Binding{k.next, k.prev, k.first, k.last}
 }
 
 func (m Model) AllKeys() [][]key.Binding {
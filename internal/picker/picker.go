@@ -1,7 +1,9 @@
 package picker
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ChausseBenjamin/termpicker/internal/colors"
@@ -9,12 +11,18 @@ import (
 	"github.com/ChausseBenjamin/termpicker/internal/ui"
 	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	lg "github.com/charmbracelet/lipgloss/v2"
 )
 
+var errUnknownComponent = errors.New("unknown color component")
+
 type Model struct {
 	title   string
 	active  int
 	sliders []slider.Model
+	drag    bool // Whether the active slider is being dragged with the mouse
+	g       bool // Whether the last key was the first g of gg
 }
 
 func (m *Model) Next() int {
@@ -112,6 +120,55 @@ func (m Model) SetColor(c colors.ColorSpace) {
 	}
 }
 
+// SetSteps sets the coarse and fine steps of the slider of a component (ex:
+// "C"), or of all of them when component is empty. Zero steps are left as
+// they were.
+func (m Model) SetSteps(component string, coarse, fine slider.Step) error {
+	found := false
+	for i := range m.sliders {
+		if component == "" || strings.EqualFold(m.sliders[i].Label(), component) {
+			m.sliders[i].SetSteps(coarse, fine)
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %s has no %q", errUnknownComponent, m.title, component)
+	}
+	return nil
+}
+
+// SetProfile sets the color profile of the terminal the sliders are drawn on
+func (m Model) SetProfile(p colorprofile.Profile) {
+	for i := range m.sliders {
+		m.sliders[i].SetProfile(p)
+	}
+}
+
+// Quantize returns the color the picker would hold after SetColor(c), once
+// rounded to its sliders' steps. The picker itself is left untouched.
+func (m Model) Quantize(c colors.ColorSpace) colors.ColorSpace {
+	cp := m
+	cp.sliders = slices.Clone(m.sliders)
+	cp.SetColor(c)
+	return cp.GetColor()
+}
+
+// Editing reports whether a value is being typed in one of the sliders.
+// Every key then goes to it.
+func (m Model) Editing() bool {
+	_, ok := m.editing()
+	return ok
+}
+
+func (m Model) editing() (int, bool) {
+	for i, s := range m.sliders {
+		if s.Editing() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{}
 	for _, s := range m.sliders {
@@ -144,18 +201,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	keys := newKeybinds()
 	cmds := []tea.Cmd{}
 	switch msg := msg.(type) {
+	case tea.KeyReleaseMsg:
+		// Sent to all sliders below so none keeps speeding up
 	case tea.KeyMsg:
+		if i, ok := m.editing(); ok {
+			newSlider, cmd := m.sliders[i].Update(msg)
+			m.sliders[i] = newSlider.(slider.Model)
+			return m, cmd
+		}
+		g := m.g
+		m.g = false
 		switch {
 		case key.Matches(msg, keys.next):
 			m.Next()
 		case key.Matches(msg, keys.prev):
 			m.Prev()
+		case key.Matches(msg, keys.first):
+			if g {
+				m.Sel(0)
+			} else {
+				m.g = true
+			}
+		case key.Matches(msg, keys.last):
+			m.Sel(len(m.sliders) - 1)
 		default:
 			newActive, cmd := m.sliders[m.active].Update(msg)
 			m.sliders[m.active] = newActive.(slider.Model)
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...)
 		}
+	case tea.MouseMsg:
+		return m, m.mouse(msg)
 	}
 	// Keys are only sent to the active sliders
 	// However, other messages (ex: tick, resize) must be sent to all
@@ -166,3 +242,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	return m, tea.Batch(cmds...)
 }
+
+// mouse handles a mouse event, its position relative to the picker's view.
+// Clicking a bar selects its slider and sets it, holding the button drags
+// it and the wheel nudges the slider under the pointer.
+func (m *Model) mouse(msg tea.MouseMsg) tea.Cmd {
+	mouse := msg.Mouse()
+	// Sliders are drawn after the cursor column
+	s := m.sliders[m.active]
+	mouse.X -= lg.Width(ViewSlider(false, s)) - lg.Width(s.View())
+	i := mouse.Y
+	hovered := i >= 0 && i < len(m.sliders)
+
+	var fwd tea.Msg
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || !hovered {
+			return nil
+		}
+		if _, ok := m.sliders[i].BarAt(mouse.X); !ok {
+			return nil
+		}
+		m.active, m.drag = i, true
+		fwd = tea.MouseClickMsg(mouse)
+	case tea.MouseMotionMsg:
+		if !m.drag || msg.Button != tea.MouseLeft {
+			return nil
+		}
+		// The pointer may have left the slider's row while dragging
+		i = m.active
+		fwd = tea.MouseMotionMsg(mouse)
+	case tea.MouseReleaseMsg:
+		m.drag = false
+		return nil
+	case tea.MouseWheelMsg:
+		if !hovered {
+			return nil
+		}
+		fwd = tea.MouseWheelMsg(mouse)
+	default:
+		return nil
+	}
+
+	newSlider, cmd := m.sliders[i].Update(fwd)
+	m.sliders[i] = newSlider.(slider.Model)
+	return cmd
+}
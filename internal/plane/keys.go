@@ -0,0 +1,82 @@
+package plane
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	right, left, rightPrecise, leftPrecise key.Binding
+	up, down, upPrecise, downPrecise       key.Binding
+	hueInc, hueDec, hueIncPrecise          key.Binding
+	hueDecPrecise, mode                    key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		right: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "+5% x"),
+		),
+		left: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "-5% x"),
+		),
+		rightPrecise: key.NewBinding(
+			key.WithKeys("L", "shift+right"),
+			key.WithHelp("L", "+1% x"),
+		),
+		leftPrecise: key.NewBinding(
+			key.WithKeys("H", "shift+left"),
+			key.WithHelp("H", "-1% x"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "+5% y"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "-5% y"),
+		),
+		upPrecise: key.NewBinding(
+			key.WithKeys("K", "shift+up"),
+			key.WithHelp("K", "+1% y"),
+		),
+		downPrecise: key.NewBinding(
+			key.WithKeys("J", "shift+down"),
+			key.WithHelp("J", "-1% y"),
+		),
+		hueInc: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "+5% hue"),
+		),
+		hueDec: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "-5% hue"),
+		),
+		hueIncPrecise: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "+1° hue"),
+		),
+		hueDecPrecise: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "-1° hue"),
+		),
+		mode: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "HSV/OKLCH plane"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{
+		k.right, k.left, k.rightPrecise, k.leftPrecise,
+		k.up, k.down, k.upPrecise, k.downPrecise,
+		k.hueInc, k.hueDec, k.hueIncPrecise, k.hueDecPrecise, k.mode,
+	}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
@@ -0,0 +1,294 @@
+package plane
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const (
+	width  = 40 // Columns of the plane
+	height = 8  // Rows of the plane, each showing two pixels with half blocks
+
+	maxChroma = 0.37 // Right edge of the OKLCH plane (sRGB colors stop at ~0.32)
+
+	// indent is drawn before each row, lining the plane up with the sliders
+	indent = "  "
+	gap    = " " // Between the plane and the hue strip
+	strip  = 2   // Columns of the hue strip
+)
+
+// How far keys move the crosshair, as a fraction of the plane
+const (
+	stepRegular = 0.05
+	stepPrecise = 0.01
+)
+
+type mode int
+
+const (
+	modeHSV   mode = iota // Saturation right, value up
+	modeOKLCH             // Chroma right, lightness up
+)
+
+type dragging int
+
+const (
+	dragNone dragging = iota
+	dragPlane
+	dragHue
+)
+
+type state struct {
+	mode    mode
+	hue     float64 // 0-360 degrees
+	x, y    float64 // Crosshair position, from 0 to 1 with y = 1 at the top
+	drag    dragging
+	profile colorprofile.Profile
+}
+
+// Model picks colors on a 2D plane of a single hue, like the square pickers
+// of graphics apps, with the hue chosen on a strip next to it. Its state is
+// shared between copies (like the sliders of the other pickers) so
+// SetColor works on values.
+type Model struct {
+	s *state
+}
+
+func New() *Model {
+	return &Model{s: &state{hue: 0, x: 0, y: 0.5}}
+}
+
+func (m Model) Title() string { return "PLANE" }
+
+// at returns the color at x, y of the plane and whether sRGB can show it
+func (m Model) at(x, y float64) (colors.ColorSpace, bool) {
+	if m.s.mode == modeOKLCH {
+		c := colors.OKLCH{L: y, C: x * maxChroma, H: m.s.hue}
+		return c, c.InGamut()
+	}
+	return colors.HSV{H: m.s.hue, S: x, V: y}, true
+}
+
+// hueAt returns the color of the hue strip at hue h
+func (m Model) hueAt(h float64) colors.ColorSpace {
+	if m.s.mode == modeOKLCH {
+		return colors.OKLCH{L: 0.75, C: 0.15, H: h}.MapToGamut()
+	}
+	return colors.HSV{H: h, S: 1, V: 1}
+}
+
+func (m Model) GetColor() colors.ColorSpace {
+	c, ok := m.at(m.s.x, m.s.y)
+	if !ok {
+		return c.(colors.OKLCH).MapToGamut()
+	}
+	return c
+}
+
+// SetColor moves the crosshair (and the hue) to c. Grays keep the current
+// hue since they don't have any.
+func (m Model) SetColor(c colors.ColorSpace) {
+	p := c.ToPrecise()
+	if m.s.mode == modeOKLCH {
+		o := colors.OKLCH{}.FromPrecise(p).(colors.OKLCH)
+		if o.C > 1e-3 {
+			m.s.hue = o.H
+		}
+		m.s.x, m.s.y = min(o.C/maxChroma, 1), clamp(o.L)
+		return
+	}
+	hsv := colors.HSV{}.FromPrecise(p).(colors.HSV)
+	if hsv.S > 1e-3 {
+		m.s.hue = hsv.H
+	}
+	m.s.x, m.s.y = hsv.S, hsv.V
+}
+
+// SetProfile sets the color profile of the terminal the plane is drawn on
+func (m Model) SetProfile(p colorprofile.Profile) { m.s.profile = p }
+
+// Quantize returns the color the plane would hold after SetColor(c). The
+// plane itself is left untouched.
+func (m Model) Quantize(c colors.ColorSpace) colors.ColorSpace {
+	s := *m.s
+	cp := Model{s: &s}
+	cp.SetColor(c)
+	return cp.GetColor()
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, keys.right):
+			m.s.x = clamp(m.s.x + stepRegular)
+		case key.Matches(msg, keys.left):
+			m.s.x = clamp(m.s.x - stepRegular)
+		case key.Matches(msg, keys.rightPrecise):
+			m.s.x = clamp(m.s.x + stepPrecise)
+		case key.Matches(msg, keys.leftPrecise):
+			m.s.x = clamp(m.s.x - stepPrecise)
+		case key.Matches(msg, keys.up):
+			m.s.y = clamp(m.s.y + stepRegular)
+		case key.Matches(msg, keys.down):
+			m.s.y = clamp(m.s.y - stepRegular)
+		case key.Matches(msg, keys.upPrecise):
+			m.s.y = clamp(m.s.y + stepPrecise)
+		case key.Matches(msg, keys.downPrecise):
+			m.s.y = clamp(m.s.y - stepPrecise)
+		case key.Matches(msg, keys.hueInc):
+			m.turn(360 * stepRegular)
+		case key.Matches(msg, keys.hueDec):
+			m.turn(-360 * stepRegular)
+		case key.Matches(msg, keys.hueIncPrecise):
+			m.turn(1)
+		case key.Matches(msg, keys.hueDecPrecise):
+			m.turn(-1)
+		case key.Matches(msg, keys.mode):
+			c := m.GetColor()
+			m.s.mode = (m.s.mode + 1) % (modeOKLCH + 1)
+			m.SetColor(c)
+		}
+	case tea.MouseClickMsg:
+		if msg.Button == tea.MouseLeft {
+			m.s.drag = m.area(msg.X, msg.Y)
+			m.point(msg.X, msg.Y)
+		}
+	case tea.MouseMotionMsg:
+		if msg.Button == tea.MouseLeft {
+			m.point(msg.X, msg.Y)
+		}
+	case tea.MouseReleaseMsg:
+		m.s.drag = dragNone
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp, tea.MouseWheelRight:
+			m.turn(1)
+		case tea.MouseWheelDown, tea.MouseWheelLeft:
+			m.turn(-1)
+		}
+	}
+	return m, nil
+}
+
+// turn rotates the hue by deg degrees
+func (m Model) turn(deg float64) {
+	m.s.hue = math.Mod(math.Mod(m.s.hue+deg, 360)+360, 360)
+}
+
+// area returns what is drawn at x, y of the view (relative to it). Clicks
+// below the plane (ex: on the preview) don't start a drag.
+func (m Model) area(x, y int) dragging {
+	x -= lg.Width(indent)
+	switch {
+	case y < 0 || y >= height:
+		return dragNone
+	case x >= 0 && x < width:
+		return dragPlane
+	case x >= width+lg.Width(gap) && x < width+lg.Width(gap)+strip:
+		return dragHue
+	}
+	return dragNone
+}
+
+// point moves whatever is being dragged under the mouse at x, y. The
+// crosshair stays on the plane when the mouse leaves it.
+func (m Model) point(x, y int) {
+	switch m.s.drag {
+	case dragPlane:
+		m.s.x = clamp(float64(x-lg.Width(indent)) / (width - 1))
+		m.s.y = clamp(1 - float64(y)/(height-1))
+	case dragHue:
+		m.s.hue = 360 * clamp(float64(y)/height)
+		m.turn(0) // The bottom of the strip wraps back to red
+	}
+}
+
+func clamp(v float64) float64 { return min(max(v, 0), 1) }
+
+// pixel returns the color drawn at pixel px, py of the plane (nil when
+// outside of sRGB)
+func (m Model) pixel(px, py int) color.Color {
+	c, ok := m.at(float64(px)/(width-1), 1-float64(py)/(2*height-1))
+	if !ok {
+		return nil
+	}
+	return colors.Degrade(m.s.profile, c)
+}
+
+// halfBlock draws two pixels stacked in a cell, nil ones being left blank
+func halfBlock(top, bottom color.Color) string {
+	switch {
+	case top == nil && bottom == nil:
+		return " "
+	case bottom == nil:
+		return lg.NewStyle().Foreground(top).Render("▀")
+	case top == nil:
+		return lg.NewStyle().Foreground(bottom).Render("▄")
+	}
+	return lg.NewStyle().Foreground(top).Background(bottom).Render("▀")
+}
+
+func (m Model) View() string {
+	cx := int(math.Round(m.s.x * (width - 1)))
+	cy := int(math.Round((1 - m.s.y) * (2*height - 1)))
+	hueRow := min(int(m.s.hue/360*height), height-1)
+
+	lines := make([]string, 0, height+1)
+	for row := range height {
+		var line strings.Builder
+		line.WriteString(indent)
+		for col := range width {
+			if col == cx && row == cy/2 {
+				line.WriteString(m.crosshair())
+				continue
+			}
+			line.WriteString(halfBlock(m.pixel(col, 2*row), m.pixel(col, 2*row+1)))
+		}
+
+		line.WriteString(gap)
+		top := colors.Degrade(m.s.profile, m.hueAt(360*float64(2*row)/(2*height)))
+		bottom := colors.Degrade(m.s.profile, m.hueAt(360*float64(2*row+1)/(2*height)))
+		line.WriteString(strings.Repeat(halfBlock(top, bottom), strip))
+		if row == hueRow {
+			line.WriteString(ui.Style().PickerCursor.Render("<"))
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(append(lines, indent+m.label()), "\n")
+}
+
+// crosshair draws the cursor over the picked color
+func (m Model) crosshair() string {
+	c := m.GetColor()
+	fg := "#000000"
+	if colors.RelativeLuminance(c) < 0.18 {
+		fg = "#ffffff"
+	}
+	return lg.NewStyle().
+		Background(colors.Degrade(m.s.profile, c)).
+		Foreground(lg.Color(fg)).
+		Render("+")
+}
+
+// label describes the picked color in the plane's coordinates
+func (m Model) label() string {
+	c, ok := m.at(m.s.x, m.s.y)
+	text := fmt.Sprint(c)
+	if !ok {
+		text += " (outside sRGB)"
+	}
+	return ui.Style().Readout.Render(text)
+}
@@ -0,0 +1,53 @@
+package preview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+)
+
+// contrastView rates both previewed pairings: the picked color as text on
+// the sample background and the sample foreground as text over the picked
+// color. It is empty when neither sample color is known.
+func contrastView(hex, fgStr, bgStr string) string {
+	c, err := parse.Color(hex)
+	if err != nil {
+		return ""
+	}
+	fg, fgErr := parse.Color(fgStr)
+	bg, bgErr := parse.Color(bgStr)
+	if fgErr != nil && bgErr != nil {
+		return ""
+	}
+
+	asText := ui.Style().Readout.Render("as text        background unknown (see --bg)")
+	if bgErr == nil {
+		asText = contrastRow("as text", c, bg)
+	}
+	asBg := ui.Style().Readout.Render("as background  foreground unknown (see --fg)")
+	if fgErr == nil {
+		asBg = contrastRow("as background", fg, c)
+	}
+	return asText + "\n" + asBg
+}
+
+func contrastRow(label string, text, bg colors.ColorSpace) string {
+	ratio := colors.ContrastRatio(text, bg)
+	return strings.Join([]string{
+		ui.Style().Readout.Render(fmt.Sprintf("%-13s %5.2f:1", label, ratio)),
+		badge("AA", ratio >= colors.WCAGNormalAA),
+		badge("AAA", ratio >= colors.WCAGNormalAAA),
+		badge("AA-lg", ratio >= colors.WCAGLargeAA),
+		ui.Style().Readout.Render(fmt.Sprintf(" Lc %6.1f", colors.APCAContrast(text, bg))),
+	}, " ")
+}
+
+func badge(label string, pass bool) string {
+	if pass {
+		return ui.Style().Pass.Render(label)
+	}
+	return ui.Style().Fail.Render(label)
+}
@@ -0,0 +1,83 @@
+package preview
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// depth is how a color profile shows the previewed color
+type depth struct {
+	name  string
+	color color.Color
+	index int     // Palette index (-1 for truecolor)
+	delta float64 // ΔEok from the exact color, using xterm's default palette
+}
+
+// depths shows c the way termpicker itself degrades colors (see
+// colors.Degrade) on truecolor, 256 color and 16 color terminals, so the
+// indices match the ≈256 readout and the copied palette indices.
+func depths(c colors.ColorSpace) []depth {
+	i256, d256 := colors.Nearest256(c)
+	i16, d16 := colors.Nearest16(c)
+	return []depth{
+		{name: "truecolor", color: colors.ToColor(c), index: -1},
+		{name: "xterm-256", color: ansi.ExtendedColor(i256), index: i256, delta: d256},
+		{name: "ANSI-16", color: ansi.BasicColor(i16), index: i16, delta: d16},
+	}
+}
+
+// depthsView shows the color side by side as each color profile shows it,
+// labeled with the palette index used and how far it is from the real color.
+func (m Model) depthsView(hex string, rows int) string {
+	c, err := parse.Color(hex)
+	if err != nil {
+		return ""
+	}
+	ds := depths(c)
+	width := (m.width - len(ds) + 1) / len(ds)
+
+	var swatches, names, details []string
+	for i, d := range ds {
+		w := width
+		if i == len(ds)-1 { // Last column takes what division left over
+			w = m.width - (width+1)*(len(ds)-1)
+		}
+		label := lipgloss.NewStyle().Width(w)
+		swatches = append(swatches, lipgloss.NewStyle().
+			Background(d.color).
+			Width(w).
+			Height(rows).
+			Render(""))
+		if d.index < 0 {
+			names = append(names, label.Render(ui.Style().Readout.Render(d.name)))
+			details = append(details, label.Render(ui.Style().Readout.Render(colors.Hex(c))))
+			continue
+		}
+		names = append(names, label.Render(ui.Style().Readout.Render(fmt.Sprintf("%s: %d", d.name, d.index))))
+		details = append(details, label.Render(ui.Style().Readout.Render(fmt.Sprintf("ΔEok %.3f", d.delta))))
+	}
+	return strings.Join([]string{
+		lipgloss.JoinHorizontal(lipgloss.Top, intersperse(swatches)...),
+		lipgloss.JoinHorizontal(lipgloss.Top, intersperse(names)...),
+		lipgloss.JoinHorizontal(lipgloss.Top, intersperse(details)...),
+	}, "\n")
+}
+
+// intersperse separates columns with a space
+func intersperse(cols []string) []string {
+	out := make([]string, 0, 2*len(cols)-1)
+	for i, c := range cols {
+		if i > 0 {
+			out = append(out, " ")
+		}
+		out = append(out, c)
+	}
+	return out
+}
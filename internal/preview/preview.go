@@ -1,12 +1,17 @@
 package preview
 
 import (
+	"fmt"
+	"image/color"
 	"log/slog"
 	"strings"
 
 	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
 	"github.com/ChausseBenjamin/termpicker/internal/util"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/lipgloss/v2"
 )
 
@@ -23,6 +28,10 @@ type Model struct {
 	width  int
 	hex    string
 	cfg    Config
+	sim    *colors.CVD // Color vision deficiency to simulate (nil when off)
+	// Color profile of the terminal, colors are approximated without truecolor
+	profile colorprofile.Profile
+	depths  bool // Whether the color is shown side by side at every color depth
 }
 
 type Config struct {
@@ -33,6 +42,22 @@ type Config struct {
 
 func (m *Model) SetColor(hex string) { m.hex = hex }
 
+func (m Model) Config() Config { return m.cfg }
+
+// SetSimulation renders the preview as seen with a color vision deficiency.
+// A nil value restores normal vision.
+func (m *Model) SetSimulation(sim *colors.CVD) { m.sim = sim }
+
+// SetProfile sets the color profile of the terminal. Without truecolor,
+// the preview shows the nearest colors of the terminal's palette.
+func (m *Model) SetProfile(p colorprofile.Profile) { m.profile = p }
+
+// SetDepths shows the color as truecolor, 256 color and 16 color terminals
+// would, side by side.
+func (m *Model) SetDepths(on bool) { m.depths = on }
+
+func (m Model) Depths() bool { return m.depths }
+
 func (m *Model) SetHeight(size int) { m.height = size }
 
 func (m *Model) SetWidth(size int) { m.width = size }
@@ -42,6 +67,8 @@ func New(hex string) *Model {
 		height: defaultHeight,
 		width:  defaultWidth,
 		hex:    hex,
+		// Assumed until told otherwise, like the rest of the UI
+		profile: colorprofile.TrueColor,
 		cfg: Config{
 			PreviewStr: util.DefaultPreviewText,
 			PreviewFg:  "#ffffff",
@@ -74,10 +101,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// simulate converts a color as seen with the simulated deficiency. Colors
+// that can't be parsed are left as-is.
+func (m Model) simulate(s string) string {
+	if m.sim == nil {
+		return s
+	}
+	c, err := parse.Color(s)
+	if err != nil {
+		return s
+	}
+	return colors.Hex(m.sim.Simulate(c))
+}
+
+// color returns what the terminal can show for s
+func (m Model) color(s string) color.Color {
+	c, err := parse.Color(s)
+	if err != nil || !colors.Approximated(m.profile) {
+		return lipgloss.Color(s)
+	}
+	return colors.Degrade(m.profile, c)
+}
+
+// approxView warns that the preview only shows the nearest colors the
+// terminal has (see the ≈256 and ≈16 readouts)
+func (m Model) approxView() string {
+	n := 256
+	if m.profile == colorprofile.ANSI {
+		n = 16
+	}
+	return fmt.Sprintf("approximated: the terminal only shows %d colors", n)
+}
+
 func (m Model) View() string {
+	hex := m.simulate(m.hex)
+	fg := m.simulate(m.cfg.PreviewFg)
+	bg := m.simulate(m.cfg.PreviewBg)
+
 	normStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color(m.hex)).
-		Foreground(lipgloss.Color(m.cfg.PreviewFg)).
+		Background(m.color(hex)).
+		Foreground(m.color(fg)).
 		Align(lipgloss.Center).
 		Width(m.width)
 	var buffer = 0
@@ -88,8 +151,8 @@ func (m Model) View() string {
 		// The inverted style will use the target color as a foreground
 		// (text) with a predefined comparison color.
 		invStyle := normStyle.
-			Background(lipgloss.Color(m.cfg.PreviewBg)).
-			Foreground(lipgloss.Color(m.hex)).
+			Background(m.color(bg)).
+			Foreground(m.color(hex)).
 			Align(lipgloss.Center).
 			Width(m.width)
 		buffer = 2
@@ -99,5 +162,18 @@ func (m Model) View() string {
 
 	oneRow := strings.Repeat(runeBlock, m.width) + "\n"
 	block := prevRows + normStyle.Render(strings.Repeat(oneRow, m.height-buffer))
+	if m.depths {
+		block = prevRows + m.depthsView(hex, m.height-buffer)
+	}
+	// Contrast is measured on the real colors, not the simulated ones
+	if contrast := contrastView(m.hex, m.cfg.PreviewFg, m.cfg.PreviewBg); contrast != "" {
+		block += "\n" + contrast
+	}
+	if m.sim != nil {
+		block += "\n" + ui.Style().Readout.Render("seen with "+m.sim.String())
+	}
+	if colors.Approximated(m.profile) {
+		block += "\n" + ui.Style().Readout.Render(m.approxView())
+	}
 	return block
 }
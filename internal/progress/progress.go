@@ -14,6 +14,7 @@ import (
 
 	"github.com/ChausseBenjamin/termpicker/internal/colors"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/harmonica"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
@@ -238,6 +239,9 @@ type Model struct {
 	// of the progress bar. When false, the width of the gradient will be set
 	// to the full width of the progress bar.
 	scaleRamp bool
+
+	// Colors are degraded to what the terminal can show
+	profile colorprofile.Profile
 }
 
 // New returns a model with default values.
@@ -358,6 +362,20 @@ func (m Model) ViewAs(percent float64) string {
 	return b.String()
 }
 
+// SetProfile sets the color profile of the terminal. Without truecolor, the
+// bar is drawn with the nearest colors of the terminal's palette.
+func (m *Model) SetProfile(p colorprofile.Profile) {
+	m.profile = p
+}
+
+// approx returns the color the terminal can show for c
+func (m Model) approx(c color.Color) color.Color {
+	if c == nil || !colors.Approximated(m.profile) {
+		return c
+	}
+	return colors.Degrade(m.profile, colors.FromColor(c))
+}
+
 // SetWidth sets the width of the progress bar.
 func (m *Model) SetWidth(w int) {
 	m.width = w
@@ -368,6 +386,11 @@ func (m Model) Width() int {
 	return m.width
 }
 
+// BarWidth returns the width of the bar itself, without the percentage.
+func (m Model) BarWidth() int {
+	return max(0, m.width-ansi.StringWidth(m.percentageView(m.percentShown)))
+}
+
 func (m *Model) nextFrame() tea.Cmd {
 	return tea.Tick(time.Second/time.Duration(fps), func(time.Time) tea.Msg {
 		return FrameMsg{id: m.id, tag: m.tag}
@@ -419,13 +442,13 @@ func (m Model) barView(b *strings.Builder, percent float64, textWidth int) {
 				}
 
 				// Use half-block character: foreground = left half, background = right half
-				b.WriteString(lipgloss.NewStyle().Foreground(leftColor).Background(rightColor).Render("▌"))
+				b.WriteString(lipgloss.NewStyle().Foreground(m.approx(leftColor)).Background(m.approx(rightColor)).Render("▌"))
 			} else {
 				// For edge blocks (using the eights), keep original logic
 				if m.useGradientFunc {
 					current := float64(i) / float64(tw)
 					c := m.gradientFunc(percent, current)
-					b.WriteString(lipgloss.NewStyle().Foreground(c).Render(string(step.rune)))
+					b.WriteString(lipgloss.NewStyle().Foreground(m.approx(c)).Render(string(step.rune)))
 				} else if m.useRamp {
 					// Legacy gradient support
 					p := float64(i) / float64(tw-1)
@@ -433,16 +456,16 @@ func (m Model) barView(b *strings.Builder, percent float64, textWidth int) {
 						p = float64(i) / float64(tw-1)
 					}
 					c := m.rampColorA.BlendLuv(m.rampColorB, p)
-					b.WriteString(lipgloss.NewStyle().Foreground(c).Render(string(step.rune)))
+					b.WriteString(lipgloss.NewStyle().Foreground(m.approx(c)).Render(string(step.rune)))
 				} else {
 					// Legacy solid color support
-					b.WriteString(lipgloss.NewStyle().Foreground(m.FullColor).Render(string(step.rune)))
+					b.WriteString(lipgloss.NewStyle().Foreground(m.approx(m.FullColor)).Render(string(step.rune)))
 				}
 			}
 		} else {
 			// Empty cell - always use static color, no gradient
 			emptyStep := m.FillSteps[0]
-			b.WriteString(lipgloss.NewStyle().Foreground(m.EmptyColor).Render(string(emptyStep.rune)))
+			b.WriteString(lipgloss.NewStyle().Foreground(m.approx(m.EmptyColor)).Render(string(emptyStep.rune)))
 		}
 	}
 }
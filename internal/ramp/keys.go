@@ -0,0 +1,35 @@
+package ramp
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, ease key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next shade"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev shade"),
+		),
+		ease: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "toggle chroma easing"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.ease}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
@@ -0,0 +1,81 @@
+package ramp
+
+import (
+	"strconv"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Model shows the 50-950 ramp of the switcher's color. Sending it a
+// colors.ColorSpace changes the base color.
+type Model struct {
+	base  colors.ColorSpace
+	ease  bool
+	strip swatches.Model
+}
+
+func New(base colors.ColorSpace, ease bool) *Model {
+	m := &Model{
+		base:  base,
+		ease:  ease,
+		strip: *swatches.New(),
+	}
+	m.generate()
+	// Start on the shade the base color became
+	for i, s := range m.strip.Swatches() {
+		if colors.Hex(s.Color) == colors.Hex(base) {
+			m.strip.Sel(i)
+		}
+	}
+	return m
+}
+
+func (m *Model) generate() {
+	shades := colors.Ramp(m.base, m.ease)
+	s := make([]swatches.Swatch, len(shades))
+	for i, shade := range shades {
+		s[i] = swatches.Swatch{Color: shade.Color, Name: strconv.Itoa(shade.Step)}
+	}
+	m.strip.SetSwatches(s)
+}
+
+// Selected is the shade under the cursor
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	s, ok := m.strip.Selected()
+	return s.Color, ok
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.strip.Next()
+		case key.Matches(msg, keys.prev):
+			m.strip.Prev()
+		case key.Matches(msg, keys.ease):
+			m.ease = !m.ease
+			m.generate()
+		}
+	case colors.ColorSpace:
+		m.base = msg
+		m.generate()
+	}
+	return m, nil
+}
+
+func (m Model) View(width int) string {
+	m.strip.SetWidth(width)
+	title := "OKLCH ramp"
+	if m.ease {
+		title += " (eased chroma)"
+	}
+	return ui.Style().Readout.Render(title) + "\n" + m.strip.View()
+}
@@ -0,0 +1,69 @@
+package slider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Editing reports whether a value is being typed in the slider. Every key
+// then goes to its field.
+func (m Model) Editing() bool { return m.editing }
+
+// startEdit opens a field in place of the slider's value
+func (m *Model) startEdit() tea.Cmd {
+	m.editing, m.editErr = true, nil
+	m.field = textinput.New()
+	m.field.Prompt = ui.SliderEditPrompt
+	m.field.Placeholder = m.value(m.current)
+	m.field.Styles.Focused.Prompt = ui.Style().PickerCursor
+	// The field takes the same room as the value it replaces
+	m.field.SetWidth(lg.Width(m.ViewValue(m.max)) - lg.Width(ui.SliderEditPrompt) - 1)
+	return m.field.Focus()
+}
+
+// updateField edits the typed value, setting the slider to it on enter
+func (m Model) updateField(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch {
+	case key.Matches(msg, keys.cancel):
+		m.editing = false
+		return m, nil
+	case key.Matches(msg, keys.confirm):
+		v, err := parse.SliderValue(m.field.Value(), m.current, m.max, m.scale)
+		if err != nil {
+			m.editErr = err
+			return m, nil
+		}
+		m.editing = false
+		m.Set(v)
+		return m, m.progress.SetPercent(m.Pcnt())
+	}
+	m.editErr = nil
+	field, cmd := m.field.Update(msg)
+	m.field = field
+	return m, cmd
+}
+
+// editView shows the field where the value was, with what can be typed (or
+// why it was refused) over the bar
+func (m Model) editView() string {
+	w := m.progress.Width()
+	hint := ui.Style().Readout.Render(fmt.Sprintf("0-%s, +n -n *n /n or n%%", m.value(m.max)))
+	if m.editErr != nil {
+		hint = ui.Style().Fail.Render(m.editErr.Error())
+	}
+	hint = ansi.Truncate(hint, w, "…")
+	return strings.Join([]string{
+		ui.Style().SliderLabel.Render(m.Title()),
+		hint + strings.Repeat(" ", max(w-lg.Width(hint), 0)),
+		lg.NewStyle().Width(lg.Width(m.ViewValue(m.max))).Render(m.field.View()),
+	}, " ")
+}
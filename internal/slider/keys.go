@@ -7,6 +7,11 @@ type keybinds struct {
 	decRegular key.Binding
 	incPrecise key.Binding
 	decPrecise key.Binding
+	min        key.Binding
+	max        key.Binding
+	edit       key.Binding
+	confirm    key.Binding
+	cancel     key.Binding
 }
 
 func newKeybinds() keybinds {
@@ -27,6 +32,26 @@ func newKeybinds() keybinds {
 			key.WithKeys("shift+left", "H"),
 			key.WithHelp("H", "-1 slider"),
 		),
+		min: key.NewBinding(
+			key.WithKeys("0", "home"),
+			key.WithHelp("0", "slider min"),
+		),
+		max: key.NewBinding(
+			key.WithKeys("$", "end"),
+			key.WithHelp("$", "slider max"),
+		),
+		edit: key.NewBinding(
+			key.WithKeys("=", "enter"),
+			key.WithHelp("=", "type a value"),
+		),
+		confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "set the value"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
 	}
 }
 
@@ -40,12 +65,29 @@ func Keys() []key.Binding {
 		k.decRegular,
 		k.incPrecise,
 		k.decPrecise,
+		k.min,
+		k.max,
+		k.edit,
 	}
 }
 
 // AllKeys returns key.Bindings for the Model
 // and all of its active children. The parent
-// can use this to generate help text.
+// can use this to generate help text (with the
+// slider's own steps).
 func (m Model) AllKeys() [][]key.Binding {
-	return [][]key.Binding{Keys()}
+	k := newKeybinds()
+	k.incRegular.SetHelp("l", "+"+m.coarse.String()+" slider")
+	k.decRegular.SetHelp("h", "-"+m.coarse.String()+" slider")
+	k.incPrecise.SetHelp("L", "+"+m.fine.String()+" slider")
+	k.decPrecise.SetHelp("H", "-"+m.fine.String()+" slider")
+	return [][]key.Binding{{
+		k.incRegular,
+		k.decRegular,
+		k.incPrecise,
+		k.decPrecise,
+		k.min,
+		k.max,
+		k.edit,
+	}}
 }
@@ -2,12 +2,17 @@ package slider
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/ChausseBenjamin/termpicker/internal/progress"
 	"github.com/ChausseBenjamin/termpicker/internal/ui"
 	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	lg "github.com/charmbracelet/lipgloss/v2"
 )
 
 type Model struct {
@@ -16,6 +21,13 @@ type Model struct {
 	max      int
 	current  int
 	mappings keybinds
+	scale    float64 // What one unit is worth in the color component's units
+	coarse   Step
+	fine     Step
+	held     int             // Repeats of the key being held down
+	editing  bool            // Whether a value is being typed in the field
+	field    textinput.Model // Replaces the value while editing
+	editErr  error           // Why the typed value was refused
 }
 
 func New(label byte, maxVal int, opts ...progress.Option) Model {
@@ -25,6 +37,9 @@ func New(label byte, maxVal int, opts ...progress.Option) Model {
 		max:      maxVal,
 		current:  maxVal / 2,
 		mappings: newKeybinds(),
+		scale:    1,
+		coarse:   Step{Value: 0.05, Pcnt: true},
+		fine:     Step{Value: 1},
 	}
 	for _, opt := range opts {
 		opt(&slider.progress)
@@ -34,6 +49,9 @@ func New(label byte, maxVal int, opts ...progress.Option) Model {
 
 func (m Model) Title() string { return fmt.Sprintf("%c:", m.label) }
 
+// Label is the letter of the color component the slider edits
+func (m Model) Label() string { return string(m.label) }
+
 func (m Model) Init() tea.Cmd {
 	// Triggering a frame message Update here will force the progress bar to
 	// render immediately. This is necessary because progress bars only render
@@ -50,16 +68,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	keys := newKeybinds()
 
 	switch msg := msg.(type) {
+	case tea.KeyReleaseMsg:
+		m.held = 0
+		return m, nil
 	case tea.KeyMsg:
+		if m.editing {
+			return m.updateField(msg)
+		}
+		// Terminals reporting key repeats (kitty keyboard protocol) speed
+		// the steps up while a key is held down
+		if press, ok := msg.(tea.KeyPressMsg); ok && press.IsRepeat {
+			m.held++
+		} else {
+			m.held = 0
+		}
 		switch {
+		case key.Matches(msg, keys.edit):
+			return m, m.startEdit()
 		case key.Matches(msg, keys.incRegular):
-			m.IncPcnt(0.05)
+			m.Inc(m.units(m.coarse))
 		case key.Matches(msg, keys.decRegular):
-			m.DecPcnt(0.05)
+			m.Dec(m.units(m.coarse))
 		case key.Matches(msg, keys.incPrecise):
-			m.Inc(1)
+			m.Inc(m.units(m.fine))
 		case key.Matches(msg, keys.decPrecise):
-			m.Dec(1)
+			m.Dec(m.units(m.fine))
+		case key.Matches(msg, keys.min):
+			m.Set(0)
+		case key.Matches(msg, keys.max):
+			m.Set(m.max)
+		}
+		return m, m.progress.SetPercent(m.Pcnt())
+	case tea.MouseClickMsg:
+		if p, ok := m.BarAt(msg.X); ok {
+			m.SetPcnt(p)
+		}
+		return m, m.progress.SetPercent(m.Pcnt())
+	case tea.MouseMotionMsg:
+		// Dragging past either end of the bar pins the slider there
+		p, _ := m.BarAt(msg.X)
+		m.SetPcnt(p)
+		return m, m.progress.SetPercent(m.Pcnt())
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp, tea.MouseWheelRight:
+			m.Inc(m.units(m.fine))
+		case tea.MouseWheelDown, tea.MouseWheelLeft:
+			m.Dec(m.units(m.fine))
 		}
 		return m, m.progress.SetPercent(m.Pcnt())
 	case progress.FrameMsg:
@@ -70,18 +125,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.progress = progressModel.(progress.Model)
 		cmds = append(cmds, cmd)
 	default:
+		if m.editing { // Ex: the cursor blinking
+			field, cmd := m.field.Update(msg)
+			m.field = field
+			cmds = append(cmds, cmd)
+		}
 	}
 	return m, tea.Batch(cmds...)
 }
 
 func (m Model) ViewValue(current int) string {
-	return fmt.Sprintf("(%3d/%d)", current, m.max)
+	return fmt.Sprintf("(%3s/%s)", m.value(current), m.value(m.max))
+}
+
+// value formats v in the units of the slider's color component, with as
+// many decimals as one unit of the slider needs
+func (m Model) value(v int) string {
+	decimals := max(int(math.Round(-math.Log10(m.scale))), 0)
+	return strconv.FormatFloat(float64(v)*m.scale, 'f', decimals, 64)
 }
 
 func (m Model) View() string {
+	if m.editing {
+		return m.editView()
+	}
 	return strings.Join([]string{
 		ui.Style().SliderLabel.Render(m.Title()),
 		m.progress.View(),
 		ui.Style().SliderVal.Render(m.ViewValue(m.current)),
 	}, " ")
 }
+
+// BarAt returns the value, as a percentage, of the bar at column x of the
+// slider's view (clamped to the ends of the bar) and whether x is on the bar.
+func (m Model) BarAt(x int) (float64, bool) {
+	x -= lg.Width(ui.Style().SliderLabel.Render(m.Title()) + " ")
+	w := m.progress.BarWidth()
+	p := float64(x) / float64(max(w-1, 1))
+	return min(max(p, 0), 1), x >= 0 && x < w
+}
+
+// SetProfile sets the color profile of the terminal the slider is drawn on
+func (m *Model) SetProfile(p colorprofile.Profile) { m.progress.SetProfile(p) }
@@ -0,0 +1,58 @@
+package slider
+
+import (
+	"fmt"
+	"math"
+)
+
+// Holding a key down doubles its step every accelRepeats repeats, up to
+// 1<<accelMaxShift times the step
+const (
+	accelRepeats  = 10
+	accelMaxShift = 3
+)
+
+// Step is how far a key moves a slider, either in the units of the color
+// component it edits or as a fraction of the slider's range
+type Step struct {
+	Value float64
+	Pcnt  bool // Value is a fraction of the range (0.05 is 5%)
+}
+
+func (s Step) String() string {
+	if s.Pcnt {
+		return fmt.Sprintf("%.4g%%", s.Value*100)
+	}
+	return fmt.Sprintf("%.4g", s.Value)
+}
+
+// SetSteps sets the steps of the h/l and H/L keys. Zero steps are left as
+// they were.
+func (m *Model) SetSteps(coarse, fine Step) {
+	if coarse.Value > 0 {
+		m.coarse = coarse
+	}
+	if fine.Value > 0 {
+		m.fine = fine
+	}
+}
+
+// SetScale sets what one unit of the slider is worth in the units of its
+// color component (ex: 0.001 for an OKLCH chroma stored in thousandths).
+// The fine step becomes one unit of the slider.
+func (m *Model) SetScale(scale float64) {
+	m.scale = scale
+	m.fine = Step{Value: scale}
+}
+
+// units returns how many units of the slider a step moves it (at least
+// one), accelerated while its key is held down
+func (m Model) units(s Step) int {
+	var n int
+	if s.Pcnt {
+		n = int(float64(m.max) * s.Value)
+	} else {
+		n = int(math.Round(s.Value / m.scale))
+	}
+	return max(n, 1) << min(m.held/accelRepeats, accelMaxShift)
+}
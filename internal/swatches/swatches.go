@@ -0,0 +1,190 @@
+package swatches
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const (
+	defaultWidth = 55
+	minCellWidth = 3 // Narrower swatches are scrolled instead
+)
+
+// Swatch is a color shown in a strip, optionally named.
+type Swatch struct {
+	Color    colors.ColorSpace
+	Name     string
+	Notes    string
+	Notation colors.Notation // How the color is preferably written
+}
+
+// Label is the swatch's name followed by its color
+func (s Swatch) Label() string {
+	if s.Name == "" {
+		return s.Notation.Format(s.Color)
+	}
+	return s.Name + " " + s.Notation.Format(s.Color)
+}
+
+// Model is a horizontal strip of swatches with a cursor. It has no key
+// handling of its own: the views embedding it decide how it's navigated.
+type Model struct {
+	swatches []Swatch
+	active   int
+	width    int
+}
+
+func New() *Model {
+	return &Model{width: defaultWidth}
+}
+
+func (m *Model) SetWidth(size int) { m.width = size }
+
+// SetSwatches replaces the strip's content, keeping the cursor in range.
+func (m *Model) SetSwatches(s []Swatch) {
+	m.swatches = s
+	m.active = max(0, min(m.active, len(s)-1))
+}
+
+func (m Model) Swatches() []Swatch { return m.swatches }
+
+func (m Model) Len() int { return len(m.swatches) }
+
+func (m Model) Active() int { return m.active }
+
+func (m *Model) Next() int { return m.Sel(m.active + 1) }
+
+func (m *Model) Prev() int { return m.Sel(m.active - 1) }
+
+func (m *Model) Sel(i int) int {
+	m.active = m.fixSel(i)
+	return m.active
+}
+
+func (m Model) fixSel(val int) int {
+	size := len(m.swatches)
+	if size == 0 {
+		return 0
+	}
+	return (val%size + size) % size
+}
+
+// Selected returns the swatch under the cursor. The boolean is false when
+// the strip is empty.
+func (m Model) Selected() (Swatch, bool) {
+	if len(m.swatches) == 0 {
+		return Swatch{}, false
+	}
+	return m.swatches[m.active], true
+}
+
+// Insert adds a swatch after the selected one and selects it
+func (m *Model) Insert(s Swatch) {
+	i := min(m.active+1, len(m.swatches))
+	m.swatches = slices.Insert(slices.Clone(m.swatches), i, s)
+	m.active = i
+}
+
+// Delete removes the selected swatch
+func (m *Model) Delete() {
+	if len(m.swatches) == 0 {
+		return
+	}
+	m.SetSwatches(slices.Delete(slices.Clone(m.swatches), m.active, m.active+1))
+}
+
+// Set replaces the selected swatch
+func (m *Model) Set(s Swatch) {
+	if len(m.swatches) == 0 {
+		return
+	}
+	m.swatches = slices.Clone(m.swatches)
+	m.swatches[m.active] = s
+}
+
+// Move swaps the selected swatch with its neighbor, the selection follows.
+func (m *Model) Move(delta int) {
+	j := m.active + delta
+	if len(m.swatches) == 0 || j < 0 || j >= len(m.swatches) {
+		return
+	}
+	m.swatches = slices.Clone(m.swatches)
+	m.swatches[m.active], m.swatches[j] = m.swatches[j], m.swatches[m.active]
+	m.active = j
+}
+
+// window returns the range of swatches that fit in the strip, keeping the
+// selected one near the middle when they don't all fit.
+func (m Model) window() (start, end int) {
+	n := len(m.swatches)
+	visible := max(1, m.width/minCellWidth)
+	if n <= visible {
+		return 0, n
+	}
+	start = max(0, min(m.active-visible/2, n-visible))
+	return start, start + visible
+}
+
+// cellWidths splits the strip's width between n swatches, giving the
+// leftover columns to the first ones.
+func (m Model) cellWidths(n int) []int {
+	widths := make([]int, n)
+	for i := range widths {
+		widths[i] = m.width / n
+		if i < m.width%n {
+			widths[i]++
+		}
+		widths[i] = max(1, widths[i])
+	}
+	return widths
+}
+
+// At returns the swatch drawn at column x of the strip
+func (m Model) At(x int) (int, bool) {
+	start, end := m.window()
+	for j, w := range m.cellWidths(end - start) {
+		if x >= 0 && x < w {
+			return start + j, true
+		}
+		x -= w
+	}
+	return 0, false
+}
+
+// View renders the swatches, a cursor under the selected one and its label.
+func (m Model) View() string {
+	if len(m.swatches) == 0 {
+		return ui.Style().Readout.Render("(no swatches)")
+	}
+
+	start, end := m.window()
+	var cells, cursor strings.Builder
+	for j, w := range m.cellWidths(end - start) {
+		i := start + j
+		cells.WriteString(lg.NewStyle().
+			Background(lg.Color(colors.Hex(m.swatches[i].Color))).
+			Render(strings.Repeat(" ", w)))
+		mark := " "
+		if i == m.active {
+			mark = ui.SwatchSelRune
+		}
+		cursor.WriteString(lg.PlaceHorizontal(w, lg.Center, mark))
+	}
+
+	label, _ := m.Selected()
+	info := label.Label()
+	if start > 0 || end < len(m.swatches) {
+		info = fmt.Sprintf("%s (%d/%d)", info, m.active+1, len(m.swatches))
+	}
+	return strings.Join([]string{
+		cells.String(),
+		cells.String(),
+		ui.Style().PickerCursor.Render(cursor.String()),
+		ui.Style().Readout.Render(info),
+	}, "\n")
+}
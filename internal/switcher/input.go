@@ -0,0 +1,104 @@
+package switcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChausseBenjamin/termpicker/internal/theme"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// What the manual input is asking for
+const (
+	inputColor int = iota
+	inputName
+	inputNotes
+	inputOpen
+	inputSave
+	inputTheme
+	inputLive
+)
+
+var inputPlaceholders = []string{
+	inputColor: ui.PromptPlaceholder,
+	inputName:  ui.PromptNamePlaceholder,
+	inputNotes: ui.PromptNotesPlaceholder,
+	inputOpen:  ui.PromptOpenPlaceholder,
+	inputSave:  ui.PromptSavePlaceholder,
+	inputTheme: ui.PromptThemePlaceholder,
+	inputLive:  ui.PromptLivePlaceholder,
+}
+
+// prompt enters insert mode to ask for something other than a color, with
+// value as the starting text.
+func (m *Model) prompt(mode int, value string) tea.Cmd {
+	m.inputMode = mode
+	m.input.Placeholder = inputPlaceholders[mode]
+	m.input.SetValue(value)
+	return m.input.Focus()
+}
+
+// blurInput leaves insert mode, going back to color input if something else
+// was being asked for.
+func (m *Model) blurInput() {
+	m.input.Blur()
+	if m.inputMode != inputColor {
+		m.inputMode = inputColor
+		m.input.Placeholder = ui.PromptPlaceholder
+		m.input.SetValue("")
+	}
+}
+
+// submitInput leaves insert mode and uses the typed value for whatever it
+// was asked for.
+func (m *Model) submitInput() tea.Cmd {
+	value, mode := m.input.Value(), m.inputMode
+	m.blurInput()
+
+	switch mode {
+	case inputName:
+		m.palette.Rename(value)
+	case inputNotes:
+		m.palette.SetNotes(value)
+	case inputOpen:
+		if err := m.palette.Open(value); err != nil {
+			return m.NewNotice(err.Error())
+		}
+		return m.NewNotice("Opened " + value)
+	case inputSave:
+		if err := m.palette.SaveAs(value); err != nil {
+			return m.NewNotice(err.Error())
+		}
+		return m.NewNotice("Saved to " + value)
+	case inputTheme:
+		t, ok := m.panel.(theme.Model)
+		if !ok {
+			return nil
+		}
+		if value == "" {
+			return m.NewNotice("No file to export the theme to")
+		}
+		if err := os.MkdirAll(filepath.Dir(value), 0o755); err != nil {
+			return m.NewNotice(err.Error())
+		}
+		if err := util.WriteFileAtomic(value, theme.Export(t.Format(), m.theme)); err != nil {
+			return m.NewNotice(err.Error())
+		}
+		return m.NewNotice(fmt.Sprintf("Exported %s theme to %s", t.Format(), value))
+	case inputLive:
+		target, err := parseLiveTarget(value)
+		if err != nil {
+			return m.NewNotice(err.Error())
+		}
+		return m.startLive(target)
+	default:
+		return tea.Batch(
+			m.NewNotice(m.SetColorFromText(value)),
+			m.Init(), // Will force a slider update/animation
+		)
+	}
+	return nil
+}
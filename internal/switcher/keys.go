@@ -3,6 +3,9 @@ package switcher
 import (
 	"strings"
 
+	"github.com/ChausseBenjamin/termpicker/internal/base16"
+	"github.com/ChausseBenjamin/termpicker/internal/theme"
+	"github.com/ChausseBenjamin/termpicker/internal/tokens"
 	"github.com/charmbracelet/bubbles/v2/key"
 )
 
@@ -14,14 +17,23 @@ const (
 	cpOKLCH = "o"
 	cpEscFG = "f"
 	cpEscBG = "b"
+
+	cpEscFGBG = "e"
+	cpXterm   = "n"
+	cpXtermFG = "N"
 )
 
 type keybinds struct {
 	next, prev, copy, help, insert, esc, confirm, suspend, quit key.Binding
+	mark, unmark, fix, cvd                                      key.Binding
+	harmonies, ramp, gradient, palette, load, unfocus           key.Binding
+	rename, notes, open, save, tokens, saveTokens               key.Binding
+	theme, exportTheme, scheme, saveScheme, live, depths        key.Binding
+	repeat                                                      key.Binding
 }
 
 func newKeybinds() keybinds {
-	cpKeys := []string{cpHex, cpRGB, cpHSL, cpCMYK, cpOKLCH, cpEscBG, cpEscFG}
+	cpKeys := []string{cpHex, cpRGB, cpHSL, cpCMYK, cpOKLCH, cpEscBG, cpEscFG, cpEscFGBG, cpXterm, cpXtermFG}
 	return keybinds{
 		next: key.NewBinding(
 			key.WithKeys("tab"),
@@ -38,6 +50,107 @@ func newKeybinds() keybinds {
 				"copy color",
 			),
 		),
+		mark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "mark as reference"),
+		),
+		unmark: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "clear reference"),
+		),
+		fix: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "fix contrast"),
+		),
+		cvd: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "simulate color blindness"),
+		),
+		depths: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "compare color depths"),
+		),
+		live: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "apply live to the terminal"),
+		),
+		repeat: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "repeat last change"),
+		),
+		harmonies: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "color harmonies"),
+		),
+		ramp: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "shade ramp"),
+		),
+		gradient: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "gradient editor"),
+		),
+		palette: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "palette"),
+		),
+		tokens: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "design tokens"),
+		),
+		theme: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "terminal theme"),
+		),
+		scheme: key.NewBinding(
+			key.WithKeys("ctrl+b"),
+			key.WithHelp("ctrl+b", "base16 scheme"),
+		),
+		rename: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "rename swatch"),
+			key.WithDisabled(),
+		),
+		notes: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "edit swatch notes"),
+			key.WithDisabled(),
+		),
+		open: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "open palette file"),
+			key.WithDisabled(),
+		),
+		save: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "save palette file"),
+			key.WithDisabled(),
+		),
+		saveTokens: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "save tokens file"),
+			key.WithDisabled(),
+		),
+		exportTheme: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "export theme file"),
+			key.WithDisabled(),
+		),
+		saveScheme: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "save scheme file"),
+			key.WithDisabled(),
+		),
+		load: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "load swatch"),
+			key.WithDisabled(),
+		),
+		unfocus: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back to sliders"),
+			key.WithDisabled(),
+		),
 		help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -68,7 +181,7 @@ func newKeybinds() keybinds {
 
 func Keys() []key.Binding {
 	k := newKeybinds()
-	return []key.Binding{k.next, k.prev, k.copy, k.insert, k.esc, k.confirm, k.help, k.quit}
+	return []key.Binding{k.next, k.prev, k.copy, k.mark, k.unmark, k.fix, k.cvd, k.depths, k.live, k.harmonies, k.ramp, k.gradient, k.palette, k.tokens, k.theme, k.scheme, k.insert, k.esc, k.confirm, k.help, k.quit, k.repeat}
 }
 
 func shortKeys() [][]key.Binding {
@@ -86,8 +199,33 @@ func shortKeys() [][]key.Binding {
 }
 
 func (m Model) AllKeys() [][]key.Binding {
+	if p, ok := m.focused(); ok {
+		k := m.focusKeys()
+		keys := [][]key.Binding{append(Keys(), k.rename, k.notes, k.open, k.save, k.saveTokens, k.exportTheme, k.saveScheme, k.load, k.unfocus)}
+		return append(keys, p.AllKeys()...)
+	}
 	keys := make([][]key.Binding, len(m.pickers[m.active].AllKeys())+1)
 	keys[0] = Keys()
 	copy(keys[1:], m.pickers[m.active].AllKeys())
 	return keys
 }
+
+// focusKeys enables the bindings that only apply to the focused panel
+func (m Model) focusKeys() keybinds {
+	k := newKeybinds()
+	if _, ok := m.focused(); ok {
+		k.load.SetEnabled(true)
+		k.unfocus.SetEnabled(true)
+		k.rename.SetEnabled(m.focus == focusPalette && m.palette.Len() > 0)
+		k.notes.SetEnabled(m.focus == focusPalette && m.palette.Len() > 0)
+		k.open.SetEnabled(m.focus == focusPalette)
+		k.save.SetEnabled(m.focus == focusPalette)
+		_, isTokens := m.panel.(tokens.Model)
+		k.saveTokens.SetEnabled(m.focus == focusPanel && isTokens)
+		_, isTheme := m.panel.(theme.Model)
+		k.exportTheme.SetEnabled(m.focus == focusPanel && isTheme)
+		_, isScheme := m.panel.(base16.Model)
+		k.saveScheme.SetEnabled(m.focus == focusPanel && isScheme)
+	}
+	return k
+}
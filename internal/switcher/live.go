@@ -0,0 +1,122 @@
+package switcher
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/termpalette"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Terminal colors the picked color can be applied to, besides the indices
+// of its palette.
+const (
+	liveOff = -1
+	liveFg  = termpalette.Size
+	liveBg  = termpalette.Size + 1
+)
+
+var errLiveTarget = errors.New("not a terminal color")
+
+// parseLiveTarget reads a palette index, an ANSI color name, fg or bg
+func parseLiveTarget(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "fg", "foreground":
+		return liveFg, nil
+	case "bg", "background":
+		return liveBg, nil
+	}
+	for i := range 16 {
+		if s == colors.AnsiName(i) {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 || i >= termpalette.Size {
+		return liveOff, fmt.Errorf("%w: %q", errLiveTarget, s)
+	}
+	return i, nil
+}
+
+func liveName(target int) string {
+	switch {
+	case target == liveFg:
+		return "foreground"
+	case target == liveBg:
+		return "background"
+	case target < 16:
+		return fmt.Sprintf("color %d (%s)", target, colors.AnsiName(target))
+	default:
+		return fmt.Sprintf("color %d", target)
+	}
+}
+
+// startLive applies the picked color to a terminal color until stopped
+func (m *Model) startLive(target int) tea.Cmd {
+	restore := m.stopLive()
+	m.live, m.liveHex = target, ""
+	return tea.Sequence(restore, m.NewNotice("Applying the color to the terminal's "+liveName(target)))
+}
+
+// stopLive gives the terminal color being edited its original value back
+func (m *Model) stopLive() tea.Cmd {
+	if m.live == liveOff {
+		return nil
+	}
+	restore := m.restoreLive()
+	m.live, m.liveHex = liveOff, ""
+	return restore
+}
+
+// applyLive writes the picked color to the terminal when it changed
+func (m *Model) applyLive() tea.Cmd {
+	if m.live == liveOff {
+		return nil
+	}
+	c := m.pickers[m.active].GetColor()
+	hex := colors.Hex(c)
+	if hex == m.liveHex {
+		return nil
+	}
+	m.liveHex = hex
+	switch m.live {
+	case liveFg:
+		return tea.Raw(ansi.SetForegroundColor(hex))
+	case liveBg:
+		return tea.Raw(ansi.SetBackgroundColor(hex))
+	default:
+		return tea.Raw(termpalette.Set(m.live, c))
+	}
+}
+
+// restoreLive puts back the value the terminal reported for the color being
+// edited, or resets it when it never did. The next applyLive writes the
+// picked color again (ex: after a suspend).
+func (m *Model) restoreLive() tea.Cmd {
+	if m.live == liveOff || m.liveHex == "" {
+		return nil
+	}
+	m.liveHex = ""
+	switch m.live {
+	case liveFg:
+		if m.termFg == nil {
+			return tea.Raw(ansi.ResetForegroundColor)
+		}
+		return tea.Raw(ansi.SetForegroundColor(colors.Hex(m.termFg)))
+	case liveBg:
+		if m.termBg == nil {
+			return tea.Raw(ansi.ResetBackgroundColor)
+		}
+		return tea.Raw(ansi.SetBackgroundColor(colors.Hex(m.termBg)))
+	default:
+		if c, ok := m.pickers[IndexTerm].(termpalette.Model).Reported(m.live); ok {
+			return tea.Raw(termpalette.Set(m.live, c))
+		}
+		return tea.Raw(termpalette.Reset(m.live))
+	}
+}
@@ -1,38 +1,115 @@
 package switcher
 
 import (
+	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 
 	"github.com/ChausseBenjamin/termpicker/internal/colors"
 	"github.com/ChausseBenjamin/termpicker/internal/parse"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
 	"github.com/ChausseBenjamin/termpicker/internal/util"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
 )
 
-func (m Model) copyColor(format string) tea.Cmd {
-	pc := m.pickers[m.active].GetColor().ToPrecise()
-	var colorStr string
+// colorString formats the current color for the given copy key. The boolean
+// is false when the format isn't supported.
+func (m Model) colorString(format string) (string, bool) {
+	cs := m.current()
+	pc := cs.ToPrecise()
 
 	switch format {
 	case cpHex:
-		colorStr = colors.Hex(m.pickers[m.active].GetColor())
+		return colors.Hex(cs), true
 	case cpRGB:
-		rgb := colors.RGB{}.FromPrecise(pc).(colors.RGB)
-		colorStr = rgb.String()
+		return colors.RGB{}.FromPrecise(pc).(colors.RGB).String(), true
 	case cpHSL:
-		hsl := colors.HSL{}.FromPrecise(pc).(colors.HSL)
-		colorStr = hsl.String()
+		return colors.HSL{}.FromPrecise(pc).(colors.HSL).String(), true
 	case cpCMYK:
-		cmyk := colors.CMYK{}.FromPrecise(pc).(colors.CMYK)
-		colorStr = cmyk.String()
+		return colors.CMYK{}.FromPrecise(pc).(colors.CMYK).String(), true
 	case cpOKLCH:
-		oklch := colors.OKLCH{}.FromPrecise(pc).(colors.OKLCH)
-		colorStr = oklch.String()
+		return colors.OKLCH{}.FromPrecise(pc).(colors.OKLCH).String(), true
 	case cpEscFG:
-		colorStr = colors.EscapedSeq(m.pickers[m.active].GetColor(), true)
+		return m.escape.Seq(cs, true), true
 	case cpEscBG:
-		colorStr = colors.EscapedSeq(m.pickers[m.active].GetColor(), false)
+		return m.escape.Seq(cs, false), true
+	case cpEscFGBG:
+		// The sample background is used so the copied sequence matches
+		// what the preview shows. Without one, the terminal's default is kept.
+		var bg colors.ColorSpace
+		if c, err := parse.Color(m.prev.Config().PreviewBg); err == nil {
+			bg = c
+		}
+		return m.escape.Combined(cs, bg), true
+	case cpXterm:
+		i, _ := colors.Nearest256(cs)
+		return strconv.Itoa(i), true
+	case cpXtermFG:
+		opts := m.escape
+		opts.Depth = colors.Depth256
+		return opts.Seq(cs, true), true
 	default:
+		return "", false
+	}
+}
+
+// paletteMatchView shows which xterm-256 and ANSI-16 colors are closest to
+// the current color. Swatches use palette indices so they reflect the
+// terminal's actual theme rather than xterm's defaults.
+func (m Model) paletteMatchView() string {
+	cs := m.current()
+	i256, d256 := colors.Nearest256(cs)
+	i16, d16 := colors.Nearest16(cs)
+
+	swatch := func(i int) string {
+		return lg.NewStyle().Background(lg.Color(strconv.Itoa(i))).Render("  ")
+	}
+
+	return strings.Join([]string{
+		ui.Style().Readout.Render("≈256"),
+		swatch(i256),
+		ui.Style().Readout.Render(fmt.Sprintf("%d ΔEok %.3f  ≈16", i256, d256)),
+		swatch(i16),
+		ui.Style().Readout.Render(fmt.Sprintf("%d ΔEok %.3f", i16, d16)),
+	}, " ")
+}
+
+// referenceView compares the current color with the marked reference
+func (m Model) referenceView() string {
+	cs := m.current()
+	d := colors.DeltaE2000(m.ref, cs)
+	return strings.Join([]string{
+		ui.Style().Readout.Render("ref"),
+		lg.NewStyle().Background(lg.Color(colors.Hex(m.ref))).Render("  "),
+		ui.Style().Readout.Render(fmt.Sprintf("%s  ΔE00 %.2f (%s)",
+			colors.Hex(m.ref), d, colors.DescribeDeltaE2000(d),
+		)),
+	}, " ")
+}
+
+// fixContrast moves the active color to the closest one reaching the
+// contrast target against the sample background. It returns a notice
+// describing the outcome.
+func (m *Model) fixContrast() string {
+	bg, err := parse.Color(m.prev.Config().PreviewBg)
+	if err != nil {
+		return "No sample background to fix the contrast against"
+	}
+
+	p := m.pickers[m.active]
+	fixed, ok := colors.FixContrast(p.GetColor(), bg, m.target, p.Quantize)
+	if !ok {
+		return fmt.Sprintf("%s can't be reached against %s", m.target, colors.Hex(bg))
+	}
+	m.pickers[m.active].SetColor(fixed)
+	return fmt.Sprintf("Color set to %s (%s against %s)", colors.Hex(fixed), m.target, colors.Hex(bg))
+}
+
+func (m Model) copyColor(format string) tea.Cmd {
+	colorStr, ok := m.colorString(format)
+	if !ok {
 		return func() tea.Msg {
 			return util.ClipboardResultMsg{
 				Success: false,
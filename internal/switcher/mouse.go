@@ -0,0 +1,90 @@
+package switcher
+
+import (
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+func (m Model) tabTitle(i int) string {
+	if i == m.active {
+		return ui.Style().TabSel.Render(m.pickers[i].Title())
+	}
+	return ui.Style().TabNorm.Render(m.pickers[i].Title())
+}
+
+func (m Model) tabsView() string {
+	tabs := make([]string, len(m.pickers))
+	for i := range m.pickers {
+		tabs[i] = m.tabTitle(i)
+	}
+	return strings.Join([]string{
+		ui.Style().TabGeom.Render(ui.TabSepLeft),
+		strings.Join(tabs, ui.Style().TabGeom.Render(ui.TabSepMid)),
+		ui.Style().TabGeom.Render(ui.TabSepRight),
+	}, " ")
+}
+
+// tabAt returns the tab whose title is drawn at column x of the tabs
+func (m Model) tabAt(x int) (int, bool) {
+	x -= lg.Width(ui.Style().TabGeom.Render(ui.TabSepLeft) + " ")
+	sep := lg.Width(ui.Style().TabGeom.Render(ui.TabSepMid))
+	for i := range m.pickers {
+		w := lg.Width(m.tabTitle(i))
+		if x >= 0 && x < w {
+			return i, true
+		}
+		x -= w + sep
+	}
+	return 0, false
+}
+
+// mouse handles a mouse event. Clicking a tab switches to it and clicking
+// the palette selects a swatch while everything else goes to the active
+// tab, relative to its top left corner. Positions are measured on the
+// rendered UI so they hold at any width.
+func (m *Model) mouse(msg tea.MouseMsg) tea.Cmd {
+	mouse := msg.Mouse()
+	tabsHeight := lg.Height(m.tabsView())
+
+	if _, ok := msg.(tea.MouseClickMsg); ok && mouse.Y < tabsHeight {
+		if i, ok := m.tabAt(mouse.X); ok && mouse.Button == tea.MouseLeft && i != m.active {
+			cs := m.pickers[m.active].GetColor()
+			m.SetActive(i)
+			m.pickers[m.active].SetColor(cs)
+		}
+		return nil
+	}
+
+	box := ui.Style().Boxed
+	mouse.X -= box.GetBorderLeftSize() + box.GetPaddingLeft()
+	mouse.Y -= tabsHeight + box.GetBorderTopSize() + box.GetPaddingTop()
+
+	if _, ok := msg.(tea.MouseClickMsg); ok && mouse.Button == tea.MouseLeft && m.paletteShown() {
+		// The palette is drawn right below the picker and the preview
+		prev := m.prev
+		prev.SetWidth(m.width())
+		top := lg.Height(m.pickers[m.active].View()) + lg.Height(prev.View())
+		if mouse.Y >= top {
+			m.palette.Click(mouse.X, mouse.Y-top, m.width())
+			return nil
+		}
+	}
+
+	var rel tea.Msg
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		rel = tea.MouseClickMsg(mouse)
+	case tea.MouseMotionMsg:
+		rel = tea.MouseMotionMsg(mouse)
+	case tea.MouseReleaseMsg:
+		rel = tea.MouseReleaseMsg(mouse)
+	case tea.MouseWheelMsg:
+		rel = tea.MouseWheelMsg(mouse)
+	}
+	newActive, cmd := m.pickers[m.active].Update(rel)
+	m.pickers[m.active] = newActive.(tab)
+	return cmd
+}
@@ -0,0 +1,150 @@
+package switcher
+
+import (
+	"github.com/ChausseBenjamin/termpicker/internal/base16"
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/palette"
+	"github.com/ChausseBenjamin/termpicker/internal/theme"
+	"github.com/ChausseBenjamin/termpicker/internal/tokens"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// What receives the keys the switcher doesn't handle itself
+const (
+	focusPicker int = iota
+	focusPanel
+	focusPalette
+)
+
+// Panels the switcher can open, telling them apart when one is toggled
+type panelKind int
+
+const (
+	panelNone panelKind = iota
+	panelHarmonies
+	panelRamp
+	panelTheme
+	panelScheme
+	panelTokens
+	panelGradient
+)
+
+// panel is an extra tool shown below the preview (ex: harmonies). It's sent
+// the active color after every update. While focused, it receives the keys
+// the switcher doesn't handle and the copy keys act on its selection. The
+// palette works the same way but stays open alongside the other panels.
+type panel interface {
+	tea.Model
+	View(width int) string
+	Selected() (colors.ColorSpace, bool)
+	AllKeys() [][]key.Binding
+}
+
+// togglePanel opens p, a panel of the given kind. If one of the same kind is
+// already open, it gets focused instead, or closed when it already was.
+func (m *Model) togglePanel(kind panelKind, p panel) {
+	switch {
+	case m.panel == nil || m.panelKind != kind:
+		m.panel, m.panelKind, m.focus = p, kind, focusPanel
+	case m.focus != focusPanel:
+		m.focus = focusPanel
+	default:
+		m.panel, m.panelKind, m.focus = nil, panelNone, focusPicker
+	}
+}
+
+// togglePalette focuses the palette, or gives the keys back to the picker
+// when it already was.
+func (m *Model) togglePalette() {
+	if m.focus == focusPalette {
+		m.focus = focusPicker
+	} else {
+		m.focus = focusPalette
+	}
+}
+
+// focused returns the panel receiving the keys, if any
+func (m Model) focused() (panel, bool) {
+	switch m.focus {
+	case focusPanel:
+		return m.panel, m.panel != nil
+	case focusPalette:
+		return m.palette, true
+	default:
+		return nil, false
+	}
+}
+
+// updateFocused sends msg to the focused panel
+func (m *Model) updateFocused(msg tea.Msg) tea.Cmd {
+	p, ok := m.focused()
+	if !ok {
+		return nil
+	}
+	newPanel, cmd := p.Update(msg)
+	if pal, isPalette := newPanel.(palette.Model); isPalette {
+		m.palette = pal
+	} else {
+		m.panel = newPanel.(panel)
+	}
+	return cmd
+}
+
+// current is the color the copy keys act on: the focused panel's selection
+// or the active picker's color.
+func (m Model) current() colors.ColorSpace {
+	if p, ok := m.focused(); ok {
+		if c, ok := p.Selected(); ok {
+			return c
+		}
+	}
+	return m.pickers[m.active].GetColor()
+}
+
+// loadSelection sets the pickers to the focused panel's selection
+func (m *Model) loadSelection() (string, bool) {
+	p, ok := m.focused()
+	if !ok {
+		return "", false
+	}
+	c, ok := p.Selected()
+	if !ok {
+		return "", false
+	}
+	// Palette swatches are edited with the picker of their preferred format
+	if s, ok := m.palette.SelectedSwatch(); ok && m.focus == focusPalette {
+		switch s.Notation {
+		case colors.NotationHSL:
+			m.SetActive(IndexHsl)
+		case colors.NotationCMYK:
+			m.SetActive(IndexCmyk)
+		case colors.NotationOKLCH:
+			m.SetActive(IndexOklch)
+		default:
+			m.SetActive(IndexRgb)
+		}
+	}
+	// Tokens are edited with the picker of their color space. Tokens, theme
+	// colors and scheme slots then follow the picked color.
+	if m.focus == focusPanel {
+		switch p := m.panel.(type) {
+		case tokens.Model:
+			switch c.(type) {
+			case colors.HSL:
+				m.SetActive(IndexHsl)
+			case colors.OKLCH, colors.OKLab, colors.Lab:
+				m.SetActive(IndexOklch)
+			default:
+				m.SetActive(IndexRgb)
+			}
+			m.panel = p.Load()
+		case theme.Model:
+			m.panel = p.Load()
+		case base16.Model:
+			m.panel = p.Load()
+		}
+	}
+	m.pickers[m.active].SetColor(c)
+	return "Color set to " + colors.Hex(c), true
+}
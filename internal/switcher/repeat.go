@@ -0,0 +1,48 @@
+package switcher
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// maxCount caps counts so a typo doesn't send a key millions of times
+// (no slider needs more than 1000 steps anyway)
+const maxCount = 1000
+
+// countDigit adds a typed digit to the pending count. A 0 only counts once
+// a count was started, otherwise it goes to the picker (slider min).
+func (m *Model) countDigit(msg tea.KeyMsg) bool {
+	s := msg.String()
+	if len(s) != 1 || s[0] < '0' || s[0] > '9' || (s == "0" && m.count == 0) {
+		return false
+	}
+	m.count = min(m.count*10+int(s[0]-'0'), maxCount)
+	return true
+}
+
+// updatePicker sends a key to the active tab n times (once when n is 0),
+// remembering it for "." when it changed the picked color
+func (m *Model) updatePicker(msg tea.KeyMsg, n int) tea.Cmd {
+	before := m.pickers[m.active].GetColor()
+	cmds := []tea.Cmd{}
+	for range max(n, 1) {
+		newActive, cmd := m.pickers[m.active].Update(msg)
+		m.pickers[m.active] = newActive.(tab)
+		cmds = append(cmds, cmd)
+	}
+	if m.pickers[m.active].GetColor() != before {
+		m.lastKey, m.lastCount = msg, n
+	}
+	return tea.Batch(append(cmds, m.applyLive())...)
+}
+
+// repeat sends the last key that changed the color again, with its count
+// unless a new one was typed (like vim's ".")
+func (m *Model) repeat(n int) tea.Cmd {
+	if m.lastKey == nil {
+		return nil
+	}
+	if n == 0 {
+		n = m.lastCount
+	}
+	return m.updatePicker(m.lastKey, n)
+}
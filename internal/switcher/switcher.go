@@ -1,15 +1,26 @@
 package switcher
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
 
+	"github.com/ChausseBenjamin/termpicker/internal/base16"
 	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/gradient"
+	"github.com/ChausseBenjamin/termpicker/internal/harmony"
 	"github.com/ChausseBenjamin/termpicker/internal/notices"
+	"github.com/ChausseBenjamin/termpicker/internal/palette"
 	"github.com/ChausseBenjamin/termpicker/internal/picker"
+	"github.com/ChausseBenjamin/termpicker/internal/plane"
 	"github.com/ChausseBenjamin/termpicker/internal/preview"
 	"github.com/ChausseBenjamin/termpicker/internal/quit"
+	"github.com/ChausseBenjamin/termpicker/internal/ramp"
+	"github.com/ChausseBenjamin/termpicker/internal/slider"
+	"github.com/ChausseBenjamin/termpicker/internal/termpalette"
+	"github.com/ChausseBenjamin/termpicker/internal/theme"
+	"github.com/ChausseBenjamin/termpicker/internal/tokens"
 	"github.com/ChausseBenjamin/termpicker/internal/toosmall"
 	"github.com/ChausseBenjamin/termpicker/internal/ui"
 	"github.com/ChausseBenjamin/termpicker/internal/util"
@@ -25,25 +36,56 @@ const (
 	IndexHsl
 	IndexOklch
 	IndexCmyk
+	IndexTerm
+	IndexPlane
 )
 
+const defaultSamples = 8
+
+var errUnknownSpace = errors.New("no sliders for color space")
+
 type Model struct {
-	active   int
-	pickers  []picker.Model
-	prev     preview.Model
-	help     help.Model
-	input    textinput.Model
-	notice   notices.Model
-	fullHelp bool // When false, only show help for the switcher (not children)
-	oneshot  bool
+	active    int
+	pickers   []tab
+	prev      preview.Model
+	help      help.Model
+	input     textinput.Model
+	notice    notices.Model
+	escape    colors.EscapeOpts
+	ref       colors.ColorSpace // Color to compare against (nil when unset)
+	target    colors.ContrastTarget
+	cvd       int       // Simulated deficiency, offset by one (0 means off)
+	severity  float64   // Severity of the simulated deficiency
+	easeRamp  bool      // Whether ramps ease their chroma
+	samples   int       // How many colors are copied from gradients
+	panel     panel     // Extra tool shown below the preview (nil when closed)
+	panelKind panelKind // Which panel is open
+	palette   palette.Model
+	tokens    *tokens.Document  // Design tokens being edited (nil when none)
+	theme     *theme.Theme      // Terminal theme being edited
+	scheme    *base16.Scheme    // base16 scheme being edited (nil when none)
+	focus     int               // Whether keys go to the picker, the panel or the palette
+	inputMode int               // What the manual input is asking for
+	termDone  bool              // Whether the terminal had its chance to report its colors
+	termFg    colors.ColorSpace // Colors reported by the terminal (nil when unknown)
+	termBg    colors.ColorSpace
+	live      int        // Terminal color the picked color is applied to (liveOff when none)
+	liveHex   string     // Last color applied to the terminal
+	count     int        // Count typed before a picker key (0 when none)
+	lastKey   tea.KeyMsg // Last key that changed the picked color, for "."
+	lastCount int
+	fullHelp  bool // When false, only show help for the switcher (not children)
+	oneshot   bool
 }
 
 func New(oneshot bool) Model {
-	pickers := []picker.Model{ // Order MUST match the Index* constants
+	pickers := []tab{ // Order MUST match the Index* constants
 		*picker.RGB(),
 		*picker.HSL(),
 		*picker.OKLCH(),
 		*picker.CMYK(),
+		*termpalette.New(),
+		*plane.New(),
 	}
 
 	input := textinput.New()
@@ -57,6 +99,13 @@ func New(oneshot bool) Model {
 		help:     help.New(),
 		input:    input,
 		notice:   notices.New(),
+		palette:  *palette.New(),
+		theme:    theme.Default(),
+		target:   colors.ContrastTarget{WCAG: colors.WCAGNormalAA},
+		severity: 1,
+		easeRamp: true,
+		samples:  defaultSamples,
+		live:     liveOff,
 		fullHelp: false,
 		oneshot:  oneshot,
 	}
@@ -90,6 +139,79 @@ func (m *Model) UpdatePreview(cfg preview.Config) {
 	m.prev = newPrev.(preview.Model)
 }
 
+func (m *Model) SetEscapeOpts(opts colors.EscapeOpts) {
+	m.escape = opts
+}
+
+func (m *Model) SetReference(c colors.ColorSpace) {
+	m.ref = c
+}
+
+func (m *Model) SetContrastTarget(t colors.ContrastTarget) {
+	m.target = t
+}
+
+func (m *Model) SetCVDSeverity(s float64) {
+	m.severity = s
+}
+
+// cycleCVD moves on to the next simulated deficiency, wrapping back to
+// normal vision after the last one.
+func (m *Model) cycleCVD() string {
+	m.cvd = (m.cvd + 1) % (len(colors.Deficiencies()) + 1)
+	if m.cvd == 0 {
+		m.prev.SetSimulation(nil)
+		return "Color blindness simulation off"
+	}
+	sim := colors.CVD{Type: colors.Deficiencies()[m.cvd-1], Severity: m.severity}
+	m.prev.SetSimulation(&sim)
+	return "Simulating " + sim.String()
+}
+
+// SetSteps sets the coarse and fine steps of the sliders of a color space
+// (ex: "oklch"), only those of component when it isn't empty
+func (m *Model) SetSteps(space, component string, coarse, fine slider.Step) error {
+	for _, p := range m.pickers {
+		if pk, ok := p.(picker.Model); ok && strings.EqualFold(pk.Title(), space) {
+			return pk.SetSteps(component, coarse, fine)
+		}
+	}
+	return fmt.Errorf("%w: %q", errUnknownSpace, space)
+}
+
+func (m *Model) SetRampEasing(ease bool) {
+	m.easeRamp = ease
+}
+
+func (m *Model) SetGradientSamples(n int) {
+	m.samples = n
+}
+
+// OpenPalette loads the palette file at path, creating it if needed
+func (m *Model) OpenPalette(path string) error {
+	return m.palette.Open(path)
+}
+
+// OpenTokens loads the design tokens file at path
+func (m *Model) OpenTokens(path string) error {
+	doc, err := tokens.Open(path)
+	if err != nil {
+		return err
+	}
+	m.tokens = doc
+	return nil
+}
+
+// OpenScheme loads the base16 scheme at path, creating it if needed
+func (m *Model) OpenScheme(path string) error {
+	s, err := base16.Open(path)
+	if err != nil {
+		return err
+	}
+	m.scheme = s
+	return nil
+}
+
 func (m *Model) NewNotice(msg string) tea.Cmd {
 	return m.notice.New(msg)
 }
@@ -100,6 +222,9 @@ func (m Model) Init() tea.Cmd {
 	// Initialize clipboard system with terminal version query
 	cmds = append(cmds, util.InitClipboard())
 
+	// Use the terminal's own colors for the sample unless some were given
+	cmds = append(cmds, queryTerminalColors())
+
 	// The NoticeExpiryMsg is never sent to bubbletea by a tea.Cmd for the initial notices
 	// That's why we need to manually reset them here. Otherwise, they would never expire.
 	for k := range m.notice.Notices {
@@ -113,22 +238,17 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) View() string {
-	tabs := make([]string, len(m.pickers))
-	for i, p := range m.pickers {
-		if i == m.active {
-			tabs[i] = ui.Style().TabSel.Render(p.Title())
-		} else {
-			tabs[i] = ui.Style().TabNorm.Render(p.Title())
-		}
-	}
-	tabStr := strings.Join([]string{
-		ui.Style().TabGeom.Render(ui.TabSepLeft),
-		strings.Join(tabs, ui.Style().TabGeom.Render(ui.TabSepMid)),
-		ui.Style().TabGeom.Render(ui.TabSepRight),
-	}, " ")
+	tabStr := m.tabsView()
 
-	pickerStr := m.pickers[m.active].View()
-	w := lg.Width(pickerStr)
+	w := m.width()
+	pickerStr := lg.NewStyle().Width(w).Render(m.pickers[m.active].View())
+
+	// Like vim's showcmd, a count being typed shows at the end of the tabs
+	if m.count > 0 {
+		count := ui.Style().Readout.Render(fmt.Sprint(m.count))
+		pad := w + ui.Style().Boxed.GetHorizontalFrameSize() - lg.Width(tabStr) - lg.Width(count)
+		tabStr += strings.Repeat(" ", max(pad, 1)) + count
+	}
 
 	m.prev.SetWidth(w)
 	previewStr := m.prev.View()
@@ -153,9 +273,23 @@ func (m Model) View() string {
 		inputStr = ui.Style().Boxed.Render(m.input.View())
 	}
 
+	var panelStr string
+	if m.paletteShown() {
+		panelStr += m.palette.View(w) + "\n"
+	}
+	if m.panel != nil {
+		panelStr += m.panel.View(w) + "\n"
+	}
+
+	readouts := []string{m.paletteMatchView()}
+	if m.ref != nil {
+		readouts = append(readouts, m.referenceView())
+	}
+
 	mainArea := ui.Style().Boxed.Render(strings.Join([]string{
 		pickerStr,
 		previewStr,
+		panelStr + strings.Join(readouts, "\n"),
 		helpStr,
 	}, "\n"))
 
@@ -168,6 +302,17 @@ func (m Model) View() string {
 		}, "\n")
 }
 
+// width is the width of the main area's content. Tabs are at least as wide
+// as the RGB sliders so switching to a narrower one doesn't shrink the UI.
+func (m Model) width() int {
+	return max(lg.Width(m.pickers[IndexRgb].View()), lg.Width(m.pickers[m.active].View()))
+}
+
+// paletteShown tells if the palette is drawn below the preview
+func (m Model) paletteShown() bool {
+	return m.palette.Len() > 0 || m.focus == focusPalette
+}
+
 func (m Model) Fits(s tea.WindowSizeMsg) bool {
 	return s.Width >= lg.Width(m.View()) && s.Height >= lg.Height(m.View())
 }
@@ -185,8 +330,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		if !m.Fits(msg) {
 			m.notice.Notices = make(map[string]string)
-			smol := toosmall.New(m)
-			return smol.Update(msg)
+			restore := m.restoreLive() // Applied again once the UI is back
+			smol, cmd := toosmall.New(m).Update(msg)
+			return smol, tea.Batch(restore, cmd)
 		}
 
 	case preview.Config:
@@ -194,20 +340,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		m.prev = newPreview.(preview.Model)
 
+	case tea.KeyReleaseMsg:
+		// Only the sliders care about releases (to stop speeding up)
+		newActive, cmd := m.pickers[m.active].Update(msg)
+		m.pickers[m.active] = newActive.(tab)
+		return m, cmd
+
 	case tea.KeyMsg:
 
 		if m.input.Focused() && msg.String() != "ctrl+c" {
 			keys.esc.SetEnabled(true)
 			keys.confirm.SetEnabled(true)
 			if key.Matches(msg, keys.esc) {
-				m.input.Blur()
+				m.blurInput()
 			} else if key.Matches(msg, keys.confirm) {
-				m.input.Blur()
-				cmds = append(
-					cmds,
-					m.NewNotice(m.SetColorFromText(m.input.Value())),
-					m.Init(), // Will force a slider update/animation
-				)
+				cmds = append(cmds, m.submitInput())
 			}
 			newInput, cmd := m.input.Update(msg)
 			m.input = newInput
@@ -215,7 +362,99 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		if e, ok := m.pickers[m.active].(editor); ok && e.Editing() && msg.String() != "ctrl+c" {
+			newActive, cmd := m.pickers[m.active].Update(msg)
+			m.pickers[m.active] = newActive.(tab)
+			cmds = append(cmds, cmd, m.applyLive())
+			return m, tea.Batch(cmds...)
+		}
+
+		keys = m.focusKeys()
+
+		// Vim-like counts (ex: 10L) apply to the next key sent to the picker
+		if m.focus == focusPicker && m.countDigit(msg) {
+			return m, nil
+		}
+		count := m.count
+		m.count = 0
+
 		switch {
+		case key.Matches(msg, keys.unfocus):
+			m.focus = focusPicker
+
+		case key.Matches(msg, keys.rename):
+			s, _ := m.palette.SelectedSwatch()
+			cmds = append(cmds, m.prompt(inputName, s.Name))
+
+		case key.Matches(msg, keys.notes):
+			s, _ := m.palette.SelectedSwatch()
+			cmds = append(cmds, m.prompt(inputNotes, s.Notes))
+
+		case key.Matches(msg, keys.open):
+			cmds = append(cmds, m.prompt(inputOpen, m.palette.Path()))
+
+		case key.Matches(msg, keys.save):
+			path := m.palette.Path()
+			if path == "" {
+				path = m.palette.Name() + ".json"
+			}
+			cmds = append(cmds, m.prompt(inputSave, path))
+
+		case key.Matches(msg, keys.saveTokens):
+			if err := m.tokens.Save(); err != nil {
+				cmds = append(cmds, m.NewNotice(err.Error()))
+			} else {
+				cmds = append(cmds, m.NewNotice("Saved "+m.tokens.Path()))
+			}
+
+		case key.Matches(msg, keys.saveScheme):
+			if err := m.scheme.Save(); err != nil {
+				cmds = append(cmds, m.NewNotice(err.Error()))
+			} else {
+				cmds = append(cmds, m.NewNotice("Saved "+m.scheme.Path()))
+			}
+
+		case key.Matches(msg, keys.exportTheme):
+			t := m.panel.(theme.Model)
+			cmds = append(cmds, m.prompt(inputTheme, t.Format().DefaultPath(m.theme.Name)))
+
+		case key.Matches(msg, keys.load):
+			if notice, ok := m.loadSelection(); ok {
+				cmds = append(cmds,
+					m.NewNotice(notice),
+					m.pickers[m.active].Init(), // Animate the sliders to the new color
+				)
+			}
+
+		case key.Matches(msg, keys.harmonies):
+			m.togglePanel(panelHarmonies, *harmony.New(m.pickers[m.active].GetColor()))
+
+		case key.Matches(msg, keys.ramp):
+			m.togglePanel(panelRamp, *ramp.New(m.pickers[m.active].GetColor(), m.easeRamp))
+
+		case key.Matches(msg, keys.palette):
+			m.togglePalette()
+
+		case key.Matches(msg, keys.theme):
+			m.togglePanel(panelTheme, *theme.New(m.theme, theme.FormatKitty))
+
+		case key.Matches(msg, keys.scheme):
+			if m.scheme == nil {
+				cmds = append(cmds, m.NewNotice("No base16 scheme file (see --scheme)"))
+			} else {
+				m.togglePanel(panelScheme, *base16.New(m.scheme))
+			}
+
+		case key.Matches(msg, keys.tokens):
+			if m.tokens == nil {
+				cmds = append(cmds, m.NewNotice("No design tokens file (see --tokens)"))
+			} else {
+				m.togglePanel(panelTokens, *tokens.New(m.tokens))
+			}
+
+		case key.Matches(msg, keys.gradient):
+			m.togglePanel(panelGradient, *gradient.New(m.pickers[m.active].GetColor(), m.samples))
+
 		case key.Matches(msg, keys.next):
 			cs := m.pickers[m.active].GetColor()
 			m.Next()
@@ -228,39 +467,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.copy):
 			if m.oneshot {
-				pc := m.pickers[m.active].GetColor().ToPrecise()
-				var colorStr string
-
-				switch msg.String() {
-				case cpHex:
-					colorStr = colors.Hex(m.pickers[m.active].GetColor())
-				case cpRGB:
-					rgb := colors.RGB{}.FromPrecise(pc).(colors.RGB)
-					colorStr = rgb.String()
-				case cpHSL:
-					hsl := colors.HSL{}.FromPrecise(pc).(colors.HSL)
-					colorStr = hsl.String()
-				case cpCMYK:
-					cmyk := colors.CMYK{}.FromPrecise(pc).(colors.CMYK)
-					colorStr = cmyk.String()
-				case cpOKLCH:
-					oklch := colors.OKLCH{}.FromPrecise(pc).(colors.OKLCH)
-					colorStr = oklch.String()
-				case cpEscFG:
-					colorStr = colors.EscapedSeq(m.pickers[m.active].GetColor(), true)
-				case cpEscBG:
-					colorStr = colors.EscapedSeq(m.pickers[m.active].GetColor(), false)
-				default:
+				colorStr, ok := m.colorString(msg.String())
+				if !ok {
 					return m, nil
 				}
 
 				fmt.Println(colorStr)
-				return quit.Model{}, tea.Quit
+				restore := m.restoreLive()
+				return quit.Model{}, tea.Sequence(restore, tea.Quit)
 			} else {
 				cmd := m.copyColor(msg.String())
 				cmds = append(cmds, cmd)
 			}
 
+		case key.Matches(msg, keys.mark):
+			m.ref = m.current()
+			cmds = append(cmds, m.NewNotice("Reference set to "+colors.Hex(m.ref)))
+
+		case key.Matches(msg, keys.unmark):
+			m.ref = nil
+
+		case key.Matches(msg, keys.fix):
+			cmds = append(cmds,
+				m.NewNotice(m.fixContrast()),
+				m.pickers[m.active].Init(), // Animate the sliders to the new color
+			)
+
+		case key.Matches(msg, keys.cvd):
+			cmds = append(cmds, m.NewNotice(m.cycleCVD()))
+
+		case key.Matches(msg, keys.depths):
+			m.prev.SetDepths(!m.prev.Depths())
+
+		case key.Matches(msg, keys.live):
+			if m.live == liveOff {
+				cmds = append(cmds, m.prompt(inputLive, ""))
+			} else {
+				name := liveName(m.live)
+				cmds = append(cmds, tea.Sequence(m.stopLive(), m.NewNotice("Restored the terminal's "+name)))
+			}
+
 		case key.Matches(msg, keys.help):
 			m.fullHelp = !m.fullHelp
 
@@ -269,22 +515,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 
 		case key.Matches(msg, keys.suspend):
-			return m, tea.Suspend
+			restore := m.restoreLive()
+			return m, tea.Sequence(restore, tea.Suspend)
 
 		case key.Matches(msg, keys.quit):
-			return quit.Model{}, tea.Quit
+			restore := m.restoreLive()
+			return quit.Model{}, tea.Sequence(restore, tea.Quit)
+
+		case m.focus != focusPicker:
+			cmds = append(cmds, m.updateFocused(msg))
+			return m, tea.Batch(cmds...)
+
+		case key.Matches(msg, keys.repeat):
+			cmds = append(cmds, m.repeat(count))
+			return m, tea.Batch(cmds...)
 
 		default: // Update the picker
-			newActive, cmd := m.pickers[m.active].Update(msg)
-			m.pickers[m.active] = newActive.(picker.Model)
-			cmds = append(cmds, cmd)
+			cmds = append(cmds, m.updatePicker(msg, count))
 			return m, tea.Batch(cmds...)
 		}
 
+	case tea.MouseMsg:
+		cmds = append(cmds, m.mouse(msg))
+
+	case tea.ColorProfileMsg:
+		cmds = append(cmds, m.setProfile(msg.Profile))
+
+	case tea.ForegroundColorMsg:
+		m.setTerminalColor(msg.Color, false)
+
+	case tea.BackgroundColorMsg:
+		m.setTerminalColor(msg.Color, true)
+
+	case termQueryTimeoutMsg:
+		m.termDone = true
+
 	case tea.TerminalVersionMsg:
 		// Handle terminal version for clipboard decisions
 		util.HandleTerminalVersion(string(msg))
 
+	case tokens.EditErrMsg:
+		cmds = append(cmds, m.NewNotice(msg.Err.Error()))
+
 	case util.ClipboardResultMsg:
 		// Handle clipboard operation results
 		if msg.Success {
@@ -305,14 +577,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	default:
 	}
-	for i, p := range m.pickers {
-		newActive, cmd := p.Update(msg)
-		m.pickers[i] = newActive.(picker.Model)
+	// Mouse events were already given to the active tab, relative to it
+	if _, ok := msg.(tea.MouseMsg); !ok {
+		for i, p := range m.pickers {
+			newActive, cmd := p.Update(msg)
+			m.pickers[i] = newActive.(tab)
+			cmds = append(cmds, cmd)
+		}
+	}
+	// Panels follow the picked color while the preview shows the selection
+	picked := m.pickers[m.active].GetColor()
+	if m.panel != nil {
+		newPanel, cmd := m.panel.Update(picked)
+		m.panel = newPanel.(panel)
 		cmds = append(cmds, cmd)
 	}
-	// Update the preview
-	newPreview, cmd := m.prev.Update(m.pickers[m.active].GetColor())
-	cmds = append(cmds, cmd)
+	newPalette, _ := m.palette.Update(picked)
+	m.palette = newPalette.(palette.Model)
+
+	newPreview, cmd := m.prev.Update(m.current())
+	cmds = append(cmds, cmd, m.applyLive())
 	m.prev = newPreview.(preview.Model)
 	return m, tea.Batch(cmds...)
 }
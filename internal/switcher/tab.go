@@ -0,0 +1,28 @@
+package switcher
+
+import (
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// tab is one of the pickers cycled through with tab/shift+tab. Copies of a
+// tab share their state (ex: a picker's sliders) so SetColor works on
+// values.
+type tab interface {
+	tea.Model
+	View() string
+	Title() string
+	GetColor() colors.ColorSpace
+	SetColor(c colors.ColorSpace)
+	Quantize(c colors.ColorSpace) colors.ColorSpace // What SetColor(c) would end up holding
+	AllKeys() [][]key.Binding
+	SetProfile(p colorprofile.Profile) // Color profile of the terminal
+}
+
+// editor is a tab with a field of its own (ex: a slider's value being
+// typed) which takes every key while it is open
+type editor interface {
+	Editing() bool
+}
@@ -0,0 +1,72 @@
+package switcher
+
+import (
+	"image/color"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// How long the terminal gets to report its colors before they are given up
+// on. Terminals that don't support OSC 10/11 simply never answer.
+const termQueryTimeout = 500 * time.Millisecond
+
+type termQueryTimeoutMsg struct{}
+
+// queryTerminalColors asks the terminal for its default foreground and
+// background colors (OSC 10/11).
+func queryTerminalColors() tea.Cmd {
+	return tea.Batch(
+		tea.RequestForegroundColor,
+		tea.RequestBackgroundColor,
+		tea.Tick(termQueryTimeout, func(time.Time) tea.Msg { return termQueryTimeoutMsg{} }),
+	)
+}
+
+// setProfile degrades the preview and the sliders to what the terminal can
+// show. Terminals which don't advertise truecolor (ex: in some multiplexers)
+// are asked whether they support it anyway.
+func (m *Model) setProfile(p colorprofile.Profile) tea.Cmd {
+	slog.Info("Color profile", "profile", p)
+	m.prev.SetProfile(p)
+	for _, t := range m.pickers {
+		t.SetProfile(p)
+	}
+	if p == colorprofile.TrueColor {
+		return nil
+	}
+	return tea.Batch(tea.RequestCapability("RGB"), tea.RequestCapability("Tc"))
+}
+
+// setTerminalColor uses a color reported by the terminal as the sample
+// foreground or background, unless one was given on the command line.
+// Replies arriving after the timeout are ignored so the preview doesn't
+// change unexpectedly: it keeps showing the terminal's own colors.
+func (m *Model) setTerminalColor(c color.Color, background bool) {
+	if c == nil {
+		return
+	}
+	// Kept to restore the terminal after applying colors to it
+	if background {
+		m.termBg = colors.FromColor(c)
+	} else {
+		m.termFg = colors.FromColor(c)
+	}
+	if m.termDone {
+		return
+	}
+	cfg := m.prev.Config()
+	hex := colors.Hex(colors.FromColor(c))
+	if background && cfg.PreviewBg == "" {
+		cfg.PreviewBg = hex
+	} else if !background && cfg.PreviewFg == "" {
+		cfg.PreviewFg = hex
+	} else {
+		return
+	}
+	slog.Info("Using terminal color for the sample", "background", background, "color", hex)
+	m.UpdatePreview(cfg)
+}
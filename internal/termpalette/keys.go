@@ -0,0 +1,39 @@
+package termpalette
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, down, up key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next color"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev color"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "color below"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "color above"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.down, k.up}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
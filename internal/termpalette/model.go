@@ -0,0 +1,220 @@
+package termpalette
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	lg "github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/input"
+)
+
+// The palette is shown as the 16 ANSI colors (normal and bright), the six
+// slices of the 6x6x6 color cube and the grayscale ramp.
+var rows = func() [][]int {
+	rows := [][]int{span(0, 8), span(8, 8)}
+	for r := range 6 {
+		rows = append(rows, span(16+36*r, 36))
+	}
+	return append(rows, span(232, 24))
+}()
+
+func span(from, n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = from + i
+	}
+	return s
+}
+
+// indent is drawn before each row, lining the grid up with the sliders
+const indent = "  "
+
+// cellWidth is how many columns a color of the given row takes
+func cellWidth(row int) int {
+	if row < 2 {
+		return 4
+	}
+	return 1
+}
+
+type state struct {
+	colors [Size]colors.ColorSpace // nil until reported by the terminal
+	row    int
+	col    int
+	color  colors.ColorSpace // Picked color
+	asked  bool              // Whether the terminal was queried
+	// Without truecolor, entries are drawn with their index
+	profile colorprofile.Profile
+}
+
+// Model picks colors from the running terminal's palette. Its state is
+// shared between copies (like the sliders of the other pickers) so
+// SetColor works on values.
+type Model struct {
+	s *state
+}
+
+func New() *Model {
+	return &Model{s: &state{color: colors.RGB{R: 127, G: 127, B: 127}}}
+}
+
+func (m Model) Title() string { return "TERM" }
+
+func (m Model) index() int { return rows[m.s.row][m.s.col] }
+
+func (m Model) GetColor() colors.ColorSpace { return m.s.color }
+
+// SetColor picks c, moving the cursor to it when the palette has it
+func (m Model) SetColor(c colors.ColorSpace) {
+	m.s.color = c
+	hex := colors.Hex(c)
+	for r, row := range rows {
+		for col, i := range row {
+			if m.s.colors[i] != nil && colors.Hex(m.s.colors[i]) == hex {
+				m.s.row, m.s.col = r, col
+				return
+			}
+		}
+	}
+}
+
+// Reported returns the i-th color as the terminal reported it
+func (m Model) Reported(i int) (colors.ColorSpace, bool) {
+	c := m.s.colors[i]
+	return c, c != nil
+}
+
+// SetProfile sets the color profile of the terminal
+func (m Model) SetProfile(p colorprofile.Profile) { m.s.profile = p }
+
+// Quantize returns c as is: any color can be picked while on this tab
+func (m Model) Quantize(c colors.ColorSpace) colors.ColorSpace { return c }
+
+// Init queries the terminal's palette the first time around
+func (m Model) Init() tea.Cmd {
+	if m.s.asked {
+		return nil
+	}
+	m.s.asked = true
+	return tea.Raw(Query())
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.move(m.s.row, m.s.col+1)
+		case key.Matches(msg, keys.prev):
+			m.move(m.s.row, m.s.col-1)
+		case key.Matches(msg, keys.down):
+			m.moveRow(m.s.row + 1)
+		case key.Matches(msg, keys.up):
+			m.moveRow(m.s.row - 1)
+		}
+	case tea.MouseClickMsg:
+		if msg.Button == tea.MouseLeft {
+			m.click(msg.X, msg.Y)
+		}
+	case tea.MouseMotionMsg:
+		if msg.Button == tea.MouseLeft {
+			m.click(msg.X, msg.Y)
+		}
+	case input.UnknownEvent:
+		// Replies to OSC 4 aren't decoded by bubbletea
+		if i, c, ok := ParseReply(string(msg)); ok {
+			m.s.colors[i] = c
+		}
+	}
+	return m, nil
+}
+
+// move puts the cursor on a color, wrapping around the row, and picks it
+func (m Model) move(row, col int) {
+	n := len(rows[row])
+	m.s.row, m.s.col = row, (col%n+n)%n
+	if c := m.s.colors[m.index()]; c != nil {
+		m.s.color = c
+	}
+}
+
+// moveRow goes to the color of another row sitting under the cursor
+func (m Model) moveRow(row int) {
+	row = (row%len(rows) + len(rows)) % len(rows)
+	x := m.s.col*cellWidth(m.s.row) + cellWidth(m.s.row)/2
+	m.move(row, min(x/cellWidth(row), len(rows[row])-1))
+}
+
+// click picks the color drawn at x, y (relative to the view), if any
+func (m Model) click(x, y int) {
+	x -= lg.Width(indent)
+	if y < 0 || y >= len(rows) || x < 0 {
+		return
+	}
+	if col := x / cellWidth(y); col < len(rows[y]) {
+		m.move(y, col)
+	}
+}
+
+func (m Model) View() string {
+	lines := make([]string, 0, len(rows)+1)
+	for r, row := range rows {
+		w := cellWidth(r)
+		line := ""
+		for col, i := range row {
+			c := m.s.colors[i]
+			text := strings.Repeat(" ", w)
+			cursor := r == m.s.row && col == m.s.col
+			if cursor {
+				text = ui.PickerSelRune + strings.Repeat(" ", w-1)
+			}
+			switch {
+			case c == nil && !cursor:
+				line += ui.Style().Readout.Render(strings.Repeat("·", w))
+			case c == nil:
+				line += ui.Style().PickerCursor.Render(text)
+			default:
+				fg := "#000000"
+				if colors.RelativeLuminance(c) < 0.18 {
+					fg = "#ffffff"
+				}
+				var bg color.Color = lg.Color(colors.Hex(c))
+				if colors.Approximated(m.s.profile) {
+					bg = ansi.ExtendedColor(i)
+				}
+				line += lg.NewStyle().
+					Background(bg).
+					Foreground(lg.Color(fg)).
+					Render(text)
+			}
+		}
+		lines = append(lines, indent+line)
+	}
+	return strings.Join(append(lines, indent+m.label()), "\n")
+}
+
+// label describes the color under the cursor
+func (m Model) label() string {
+	i := m.index()
+	name := fmt.Sprintf("%d", i)
+	if i < 16 {
+		name += " " + colors.AnsiName(i)
+	}
+	c := m.s.colors[i]
+	switch {
+	case !m.s.asked || c == nil:
+		return ui.Style().Readout.Render(name + " not reported by the terminal (OSC 4)")
+	case colors.Hex(c) != colors.Hex(m.s.color):
+		return ui.Style().Readout.Render(fmt.Sprintf("%s %s (picked %s)", name, colors.Hex(c), colors.Hex(m.s.color)))
+	default:
+		return ui.Style().Readout.Render(name + " " + colors.Hex(c))
+	}
+}
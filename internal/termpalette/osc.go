@@ -0,0 +1,65 @@
+package termpalette
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+)
+
+// Size is how many colors a terminal palette has
+const Size = 256
+
+// Query asks the terminal for every color of its palette (OSC 4). Each
+// index is queried separately since not every terminal accepts several
+// indices in a single sequence.
+func Query() string {
+	var sb strings.Builder
+	for i := range Size {
+		fmt.Fprintf(&sb, "\x1b]4;%d;?\x07", i)
+	}
+	return sb.String()
+}
+
+// ParseReply reads a terminal's answer to Query for a single index, such
+// as "\x1b]4;12;rgb:5c5c/5c5c/ffff\x07".
+func ParseReply(s string) (int, colors.ColorSpace, bool) {
+	s, ok := strings.CutPrefix(s, "\x1b]")
+	if !ok {
+		s, ok = strings.CutPrefix(s, "\x9d")
+	}
+	if !ok {
+		return 0, nil, false
+	}
+	for _, st := range []string{"\x07", "\x1b\\", "\x9c"} {
+		s = strings.TrimSuffix(s, st)
+	}
+
+	s, ok = strings.CutPrefix(s, "4;")
+	index, spec, found := strings.Cut(s, ";")
+	if !ok || !found {
+		return 0, nil, false
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= Size {
+		return 0, nil, false
+	}
+	c, err := parse.Color(spec)
+	if err != nil {
+		return 0, nil, false
+	}
+	return i, c, true
+}
+
+// Set changes a color of the terminal's palette (OSC 4)
+func Set(i int, c colors.ColorSpace) string {
+	rgb := colors.RGB{}.FromPrecise(c.ToPrecise()).(colors.RGB)
+	return fmt.Sprintf("\x1b]4;%d;rgb:%02x/%02x/%02x\x07", i, rgb.R, rgb.G, rgb.B)
+}
+
+// Reset puts a color of the terminal's palette back to its default (OSC 104)
+func Reset(i int) string {
+	return fmt.Sprintf("\x1b]104;%d\x07", i)
+}
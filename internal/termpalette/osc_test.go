@@ -0,0 +1,62 @@
+package termpalette
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+)
+
+func TestQuery(t *testing.T) {
+	q := Query()
+	if n := strings.Count(q, "\x1b]4;"); n != Size {
+		t.Errorf("Query() asks for %d colors, want %d", n, Size)
+	}
+	if !strings.HasPrefix(q, "\x1b]4;0;?\x07") || !strings.HasSuffix(q, "\x1b]4;255;?\x07") {
+		t.Errorf("Query() = %q...", q[:20])
+	}
+}
+
+func TestSetRoundTrip(t *testing.T) {
+	c := colors.RGB{R: 92, G: 92, B: 255}
+	i, got, ok := ParseReply(Set(12, c))
+	if !ok || i != 12 || colors.Hex(got) != colors.Hex(c) {
+		t.Errorf("ParseReply(Set(12, %s)) = %d %v %v", colors.Hex(c), i, got, ok)
+	}
+	if r := Reset(3); r != "\x1b]104;3\x07" {
+		t.Errorf("Reset(3) = %q", r)
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		index int
+		hex   string
+		ok    bool
+	}{
+		{"BEL terminated", "\x1b]4;12;rgb:5c5c/5c5c/ffff\x07", 12, "#5C5CFF", true},
+		{"ST terminated", "\x1b]4;0;rgb:0000/0000/0000\x1b\\", 0, "#000000", true},
+		{"8-bit OSC", "\x9d4;255;rgb:ee/ee/ee\x9c", 255, "#EEEEEE", true},
+		{"hex reply", "\x1b]4;3;#cdcd00\x07", 3, "#CDCD00", true},
+		{"other OSC", "\x1b]10;rgb:ffff/ffff/ffff\x07", 0, "", false},
+		{"out of range", "\x1b]4;256;rgb:ffff/ffff/ffff\x07", 0, "", false},
+		{"bad color", "\x1b]4;1;?\x07", 0, "", false},
+		{"not an OSC", "\x1b[?1;2c", 0, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i, c, ok := ParseReply(test.reply)
+			if ok != test.ok {
+				t.Fatalf("ParseReply(%q) ok = %v, want %v", test.reply, ok, test.ok)
+			}
+			if !ok {
+				return
+			}
+			if i != test.index || colors.Hex(c) != test.hex {
+				t.Errorf("ParseReply(%q) = %d %s, want %d %s", test.reply, i, colors.Hex(c), test.index, test.hex)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package theme
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+var errUnknownFormat = errors.New("unrecognized terminal theme format")
+
+// Format is a terminal emulator's theme file format
+type Format int
+
+const (
+	FormatKitty Format = iota
+	FormatAlacritty
+	FormatWezTerm
+	FormatFoot
+	FormatGhostty
+	FormatWindowsTerminal
+	FormatXresources
+)
+
+var formats = [][]string{
+	FormatKitty:           {"kitty"},
+	FormatAlacritty:       {"alacritty"},
+	FormatWezTerm:         {"wezterm"},
+	FormatFoot:            {"foot"},
+	FormatGhostty:         {"ghostty"},
+	FormatWindowsTerminal: {"windows-terminal", "wt"},
+	FormatXresources:      {"xresources", "xrdb"},
+}
+
+// Default file name of each format, before the theme's name
+var extensions = []string{
+	FormatKitty:           ".conf",
+	FormatAlacritty:       ".toml",
+	FormatWezTerm:         ".toml",
+	FormatFoot:            ".ini",
+	FormatGhostty:         "",
+	FormatWindowsTerminal: ".json",
+	FormatXresources:      ".Xresources",
+}
+
+func (f Format) String() string { return formats[f][0] }
+
+func FormatNames() []string {
+	list := make([]string, len(formats))
+	for i, aliases := range formats {
+		list[i] = aliases[0]
+	}
+	return list
+}
+
+func ParseFormat(s string) (Format, error) {
+	i, err := util.LookupAlias(formats, s, errUnknownFormat)
+	return Format(i), err
+}
+
+// FileName suggests a file name for a theme exported in this format
+func (f Format) FileName(name string) string {
+	return name + extensions[f]
+}
+
+// DefaultPath suggests where to export a theme in this format: termpicker's
+// themes directory in the user's config directory. It is empty when there is
+// no such directory, the path then has to be given.
+func (f Format) DefaultPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "termpicker", "themes", f.FileName(name))
+}
+
+// Names of the ANSI colors in formats which name them rather than number them
+var (
+	ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+	wtNames   = []string{"black", "red", "green", "yellow", "blue", "purple", "cyan", "white"}
+)
+
+// Export writes the theme in the given format
+func Export(f Format, t *Theme) []byte {
+	var b bytes.Buffer
+	hex := func(s Slot) string { return strings.ToLower(t.Hex(s)) }
+	bare := func(s Slot) string { return strings.TrimPrefix(hex(s), "#") }
+
+	switch f {
+	case FormatKitty:
+		fmt.Fprintf(&b, "# %s\n", t.Name)
+		fmt.Fprintf(&b, "foreground %s\n", hex(Foreground))
+		fmt.Fprintf(&b, "background %s\n", hex(Background))
+		fmt.Fprintf(&b, "cursor %s\n", hex(Cursor))
+		fmt.Fprintf(&b, "selection_background %s\n", hex(Selection))
+		for i := range Slot(16) {
+			fmt.Fprintf(&b, "color%d %s\n", i, hex(i))
+		}
+
+	case FormatAlacritty:
+		fmt.Fprintf(&b, "# %s\n", t.Name)
+		fmt.Fprintf(&b, "[colors.primary]\nforeground = %q\nbackground = %q\n\n", hex(Foreground), hex(Background))
+		fmt.Fprintf(&b, "[colors.cursor]\ncursor = %q\ntext = %q\n\n", hex(Cursor), hex(Background))
+		fmt.Fprintf(&b, "[colors.selection]\nbackground = %q\ntext = \"CellForeground\"\n", hex(Selection))
+		for _, section := range []string{"normal", "bright"} {
+			fmt.Fprintf(&b, "\n[colors.%s]\n", section)
+			for i, name := range ansiNames {
+				if section == "bright" {
+					i += 8
+				}
+				fmt.Fprintf(&b, "%s = %q\n", name, hex(Slot(i)))
+			}
+		}
+
+	case FormatWezTerm:
+		// A color scheme file, for the color_scheme_dirs option
+		quoted := func(from Slot) string {
+			list := make([]string, 8)
+			for i := range list {
+				list[i] = fmt.Sprintf("%q", hex(from+Slot(i)))
+			}
+			return "[" + strings.Join(list, ", ") + "]"
+		}
+		fmt.Fprintf(&b, "[colors]\n")
+		fmt.Fprintf(&b, "foreground = %q\nbackground = %q\n", hex(Foreground), hex(Background))
+		fmt.Fprintf(&b, "cursor_bg = %q\ncursor_border = %q\ncursor_fg = %q\n", hex(Cursor), hex(Cursor), hex(Background))
+		fmt.Fprintf(&b, "selection_bg = %q\nselection_fg = %q\n", hex(Selection), hex(Foreground))
+		fmt.Fprintf(&b, "ansi = %s\nbrights = %s\n", quoted(0), quoted(8))
+		fmt.Fprintf(&b, "\n[metadata]\nname = %q\n", t.Name)
+
+	case FormatFoot:
+		fmt.Fprintf(&b, "# %s\n", t.Name)
+		fmt.Fprintf(&b, "[cursor]\ncolor=%s %s\n\n", bare(Background), bare(Cursor))
+		fmt.Fprintf(&b, "[colors]\nforeground=%s\nbackground=%s\n", bare(Foreground), bare(Background))
+		for i := range Slot(16) {
+			if i < 8 {
+				fmt.Fprintf(&b, "regular%d=%s\n", i, bare(i))
+			} else {
+				fmt.Fprintf(&b, "bright%d=%s\n", i-8, bare(i))
+			}
+		}
+		fmt.Fprintf(&b, "selection-foreground=%s\nselection-background=%s\n", bare(Foreground), bare(Selection))
+
+	case FormatGhostty:
+		fmt.Fprintf(&b, "# %s\n", t.Name)
+		for i := range Slot(16) {
+			fmt.Fprintf(&b, "palette = %d=%s\n", i, hex(i))
+		}
+		fmt.Fprintf(&b, "background = %s\nforeground = %s\n", hex(Background), hex(Foreground))
+		fmt.Fprintf(&b, "cursor-color = %s\n", hex(Cursor))
+		fmt.Fprintf(&b, "selection-background = %s\nselection-foreground = %s\n", hex(Selection), hex(Foreground))
+
+	case FormatWindowsTerminal:
+		// A scheme, to add to the "schemes" list of settings.json
+		lines := []string{
+			fmt.Sprintf("%q: %q", "name", t.Name),
+			fmt.Sprintf("%q: %q", "foreground", hex(Foreground)),
+			fmt.Sprintf("%q: %q", "background", hex(Background)),
+			fmt.Sprintf("%q: %q", "cursorColor", hex(Cursor)),
+			fmt.Sprintf("%q: %q", "selectionBackground", hex(Selection)),
+		}
+		for i, name := range wtNames {
+			lines = append(lines, fmt.Sprintf("%q: %q", name, hex(Slot(i))))
+		}
+		for i, name := range wtNames {
+			name = "bright" + strings.ToUpper(name[:1]) + name[1:]
+			lines = append(lines, fmt.Sprintf("%q: %q", name, hex(Slot(i+8))))
+		}
+		fmt.Fprintf(&b, "{\n    %s\n}\n", strings.Join(lines, ",\n    "))
+
+	case FormatXresources:
+		fmt.Fprintf(&b, "! %s\n", t.Name)
+		fmt.Fprintf(&b, "*.foreground: %s\n*.background: %s\n", hex(Foreground), hex(Background))
+		fmt.Fprintf(&b, "*.cursorColor: %s\n*.highlightColor: %s\n", hex(Cursor), hex(Selection))
+		for i := range Slot(16) {
+			fmt.Fprintf(&b, "*.color%d: %s\n", i, hex(i))
+		}
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,59 @@
+package theme
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+)
+
+func testTheme() *Theme {
+	t := Default()
+	t.Name = "test"
+	t.Colors[Magenta] = colors.RGB{R: 0xb7, G: 0x41, B: 0x6e}
+	t.Colors[Selection] = colors.RGB{R: 0x12, G: 0x34, B: 0x56}
+	return t
+}
+
+func TestExport(t *testing.T) {
+	for f, want := range map[Format][]string{
+		FormatKitty:           {"color5 #b7416e", "selection_background #123456", "background #000000"},
+		FormatAlacritty:       {"[colors.normal]", "magenta = \"#b7416e\"", "[colors.selection]\nbackground = \"#123456\""},
+		FormatWezTerm:         {"ansi = [\"#000000\", \"#cd0000\", \"#00cd00\", \"#cdcd00\", \"#0000ee\", \"#b7416e\"", "selection_bg = \"#123456\"", "name = \"test\""},
+		FormatFoot:            {"regular5=b7416e", "bright0=7f7f7f", "selection-background=123456"},
+		FormatGhostty:         {"palette = 5=#b7416e", "selection-background = #123456"},
+		FormatWindowsTerminal: {"\"purple\": \"#b7416e\"", "\"brightPurple\": \"#ff00ff\"", "\"selectionBackground\": \"#123456\""},
+		FormatXresources:      {"*.color5: #b7416e", "*.highlightColor: #123456"},
+	} {
+		out := string(Export(f, testTheme()))
+		for _, w := range want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%v: Expected %q in:\n%s", f, w, out)
+			}
+		}
+	}
+
+	var scheme map[string]string
+	if err := json.Unmarshal(Export(FormatWindowsTerminal, testTheme()), &scheme); err != nil {
+		t.Fatalf("Expected valid JSON for Windows Terminal: %v", err)
+	}
+	if len(scheme) != 21 {
+		t.Errorf("Expected a name and 20 colors, got %d keys", len(scheme))
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range FormatNames() {
+		f, err := ParseFormat(name)
+		if err != nil || f.String() != name {
+			t.Errorf("Expected %q to parse back to itself, got %v (%v)", name, f, err)
+		}
+	}
+	if f, err := ParseFormat("WT"); err != nil || f != FormatWindowsTerminal {
+		t.Errorf("Expected WT to be Windows Terminal, got %v (%v)", f, err)
+	}
+	if _, err := ParseFormat("nope"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,47 @@
+package theme
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, down, up, put, format key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "next color"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "prev color"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "color below"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "color above"),
+		),
+		put: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "set color to picked color"),
+		),
+		format: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "next export format"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.down, k.up, k.put, k.format}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
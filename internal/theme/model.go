@@ -0,0 +1,129 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/follow"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const (
+	gridCols  = 10 // 8 ANSI colors and 2 special ones per row
+	cellWidth = 4
+)
+
+// Model edits a terminal theme laid out as a grid: the normal ANSI colors,
+// foreground and background on top, the bright ones, cursor and selection
+// below. Like design tokens, a color loaded in the pickers follows the
+// picked color until another one is loaded.
+type Model struct {
+	theme    *Theme
+	row, col int
+	editing  follow.Editing // Color following the picked color
+	picked   colors.ColorSpace
+	format   Format
+}
+
+func New(t *Theme, f Format) *Model {
+	return &Model{theme: t, format: f}
+}
+
+// slotAt returns the color shown at a position of the grid
+func slotAt(row, col int) Slot {
+	switch col {
+	case 8:
+		return []Slot{Foreground, Cursor}[row]
+	case 9:
+		return []Slot{Background, Selection}[row]
+	default:
+		return Slot(row*8 + col)
+	}
+}
+
+func (m Model) slot() Slot { return slotAt(m.row, m.col) }
+
+// Format is the format the theme is exported in
+func (m Model) Format() Format { return m.format }
+
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	return m.theme.Colors[m.slot()], true
+}
+
+// Load starts editing the selected color with the pickers
+func (m Model) Load() Model {
+	m.editing.Load(int(m.slot()))
+	return m
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.next):
+			m.col = (m.col + 1) % gridCols
+		case key.Matches(msg, keys.prev):
+			m.col = (m.col + gridCols - 1) % gridCols
+		case key.Matches(msg, keys.down), key.Matches(msg, keys.up):
+			m.row = 1 - m.row
+		case key.Matches(msg, keys.put):
+			if m.picked != nil {
+				m.theme.Colors[m.slot()] = m.picked
+			}
+		case key.Matches(msg, keys.format):
+			m.format = (m.format + 1) % Format(len(formats))
+		}
+	case colors.ColorSpace:
+		m.picked = msg
+		if i, ok := m.editing.Pick(msg); ok {
+			m.theme.Colors[i] = msg
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View(width int) string {
+	title := ui.Style().Readout.Render(fmt.Sprintf("Terminal theme %q (exports to %s)", m.theme.Name, m.format))
+
+	grid := make([]string, 0, 4)
+	for row := range 2 {
+		cells, cursor := "", ""
+		for col := range gridCols {
+			if col == 8 {
+				cells += "  "
+				cursor += "  "
+			}
+			cell := lg.NewStyle().Background(lg.Color(m.theme.Hex(slotAt(row, col)))).Render("   ")
+			cells += cell + " "
+			if row == m.row && col == m.col {
+				cursor += ui.Style().PickerCursor.Render(" " + ui.SwatchSelRune + "  ")
+			} else {
+				cursor += strings.Repeat(" ", cellWidth)
+			}
+		}
+		grid = append(grid, cells, cursor)
+	}
+
+	s := m.slot()
+	label := fmt.Sprintf("%s %s", s, m.theme.Hex(s))
+	if s < 16 {
+		label = fmt.Sprintf("%d %s", s, label)
+	}
+	if m.editing.Is(int(s)) {
+		label += " (editing)"
+	}
+
+	return strings.Join([]string{
+		title,
+		strings.Join(grid, "\n"),
+		ui.Style().Readout.Render(label),
+		m.theme.SampleView(width),
+	}, "\n")
+}
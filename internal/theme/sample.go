@@ -0,0 +1,74 @@
+package theme
+
+import (
+	"strings"
+
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+// span is a piece of the sample written in one of the theme's colors
+type span struct {
+	text string
+	fg   Slot
+	bold bool
+	bg   Slot // Background by default
+}
+
+func plain(text string) span {
+	return span{text: text, fg: Foreground, bg: Background}
+}
+
+func color(text string, fg Slot) span {
+	return span{text: text, fg: fg, bg: Background}
+}
+
+func bold(text string, fg Slot) span {
+	return span{text: text, fg: fg, bold: true, bg: Background}
+}
+
+// prompt is a typical shell prompt followed by a command
+func prompt(cmd string) []span {
+	return []span{
+		bold("user@host", Green), plain(":"), bold("~/termpicker", Blue),
+		color(" (main)", Magenta), plain(" $ " + cmd),
+	}
+}
+
+// sample mimics what terminals commonly show: a prompt, ls --color and
+// git diff output, a selection and the cursor.
+var sample = [][]span{
+	prompt("ls --color"),
+	{
+		plain("README.md  "), bold("internal/", Blue), plain("  "), bold("main.go", Green),
+		plain("  "), bold("out.tar.gz", Red), plain("  "), bold("build", Cyan), plain(" -> "), bold("out/", Blue),
+	},
+	prompt("git diff"),
+	{bold("diff --git a/main.go b/main.go", Foreground)},
+	{color("@@ -4,3 +4,3 @@", Cyan), plain(" func main() {")},
+	{color(`-    fmt.Println("hello")`, Red)},
+	{color(`+    fmt.Println("hello, world")`, Green)},
+	{color("# 2 untracked files, ", BrightBlack), bold("warning:", Yellow), color(" stale cache", BrightYellow)},
+	append(prompt(""), plain("echo "), span{text: "selected text", fg: Foreground, bg: Selection}, span{text: " ", fg: Background, bg: Cursor}),
+}
+
+// SampleView renders the sample in the theme's colors
+func (t *Theme) SampleView(width int) string {
+	style := func(s span) lg.Style {
+		return lg.NewStyle().
+			Foreground(lg.Color(t.Hex(s.fg))).
+			Background(lg.Color(t.Hex(s.bg))).
+			Bold(s.bold)
+	}
+	fill := lg.NewStyle().Background(lg.Color(t.Hex(Background)))
+
+	lines := make([]string, len(sample))
+	for i, spans := range sample {
+		var b strings.Builder
+		for _, s := range spans {
+			b.WriteString(style(s).Render(s.text))
+		}
+		line := lg.NewStyle().MaxWidth(width).Render(b.String())
+		lines[i] = line + fill.Render(strings.Repeat(" ", max(0, width-lg.Width(line))))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,74 @@
+package theme
+
+import (
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+)
+
+// Slot is one of the colors of a terminal theme. The first 16 are the ANSI
+// colors, in palette order.
+type Slot int
+
+const (
+	Black Slot = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+	Foreground
+
+	Background
+	Cursor
+	Selection
+)
+
+// SlotCount is how many colors a theme has
+const SlotCount = int(Selection) + 1
+
+var specialNames = []string{
+	Foreground - 16: "foreground",
+	Background - 16: "background",
+	Cursor - 16:     "cursor",
+	Selection - 16:  "selection",
+}
+
+func (s Slot) String() string {
+	if s < 16 {
+		return colors.AnsiName(int(s))
+	}
+	return specialNames[s-16]
+}
+
+// Theme holds the colors a terminal emulator lets users configure
+type Theme struct {
+	Name   string
+	Colors [SlotCount]colors.ColorSpace
+}
+
+// Default returns xterm's palette on a black background
+func Default() *Theme {
+	t := &Theme{Name: "termpicker"}
+	for i := range 16 {
+		t.Colors[i] = colors.Xterm256(i)
+	}
+	t.Colors[Foreground] = colors.Xterm256(7)
+	t.Colors[Background] = colors.Xterm256(0)
+	t.Colors[Cursor] = colors.Xterm256(7)
+	t.Colors[Selection] = colors.Xterm256(8)
+	return t
+}
+
+// Hex returns the hex code of a slot
+func (t *Theme) Hex(s Slot) string {
+	return colors.Hex(t.Colors[s])
+}
@@ -0,0 +1,295 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/util"
+)
+
+const colorType = "color"
+
+var (
+	errTokens = errors.New("invalid design tokens file")
+	errAlias  = errors.New("unresolved alias")
+	errCycle  = errors.New("circular alias")
+)
+
+// Token is a color token of a W3C Design Tokens (DTCG) file
+type Token struct {
+	Path        string // Dotted path of the token (ex: brand.primary)
+	Depth       int    // How many groups the token is nested in
+	Description string
+	Alias       string            // Path of the referenced token, if any
+	Color       colors.ColorSpace // nil for aliases and unreadable values
+	value       value             // How the value is written in the file
+	start, end  int               // Offsets of the value in the file
+}
+
+// Group returns the path of the group holding the token
+func (t Token) Group() string {
+	if i := strings.LastIndex(t.Path, "."); i >= 0 {
+		return t.Path[:i]
+	}
+	return ""
+}
+
+// Name returns the last part of the token's path
+func (t Token) Name() string {
+	return t.Path[strings.LastIndex(t.Path, ".")+1:]
+}
+
+// Document is a design tokens file. Only color tokens are read, everything
+// else is kept as is: edits are spliced into the original text so the order
+// of the keys, the unrelated tokens and the formatting all survive a save.
+type Document struct {
+	path     string
+	data     []byte
+	tokens   []Token
+	modified bool // Whether there are unsaved edits
+}
+
+// node is a group or a token while the file is being read. Types are
+// inherited from the closest group declaring one, which may come after the
+// token in the file.
+type node struct {
+	typ    string
+	parent *node
+}
+
+func (n *node) resolvedType() string {
+	for ; n != nil; n = n.parent {
+		if n.typ != "" {
+			return n.typ
+		}
+	}
+	return ""
+}
+
+// Parse reads the color tokens of a DTCG file
+func Parse(data []byte) (*Document, error) {
+	p := parser{dec: json.NewDecoder(bytes.NewReader(data)), data: data}
+	if tok, err := p.dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("%w: expected an object", errTokens)
+	}
+	if err := p.object(nil, &node{}); err != nil {
+		return nil, fmt.Errorf("%w: %w", errTokens, err)
+	}
+
+	d := &Document{data: data}
+	for _, t := range p.found {
+		if t.node.resolvedType() != colorType {
+			continue
+		}
+		t.decode(data[t.start:t.end])
+		d.tokens = append(d.tokens, t.Token)
+	}
+	return d, nil
+}
+
+// Open reads the DTCG file at path
+func Open(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	d.path = path
+	return d, nil
+}
+
+// Save writes the document back to the file it was opened from
+func (d *Document) Save() error {
+	if err := util.WriteFileAtomic(d.path, d.data); err != nil {
+		return err
+	}
+	d.modified = false
+	return nil
+}
+
+func (d *Document) Path() string { return d.path }
+
+func (d *Document) Bytes() []byte { return d.data }
+
+func (d *Document) Modified() bool { return d.modified }
+
+// Tokens lists the color tokens in the order of the file
+func (d *Document) Tokens() []Token { return d.tokens }
+
+func (d *Document) Len() int { return len(d.tokens) }
+
+func (d *Document) index(path string) int {
+	for i, t := range d.tokens {
+		if t.Path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// target follows the aliases of the i-th token up to the token holding
+// an actual color.
+func (d *Document) target(i int) (int, error) {
+	seen := map[int]bool{}
+	for d.tokens[i].Alias != "" {
+		if seen[i] {
+			return -1, fmt.Errorf("%w: %s", errCycle, d.tokens[i].Path)
+		}
+		seen[i] = true
+		alias := d.tokens[i].Alias
+		if i = d.index(alias); i < 0 {
+			return -1, fmt.Errorf("%w: {%s}", errAlias, alias)
+		}
+	}
+	return i, nil
+}
+
+// Resolve returns the color of the i-th token, following aliases
+func (d *Document) Resolve(i int) (colors.ColorSpace, error) {
+	i, err := d.target(i)
+	if err != nil {
+		return nil, err
+	}
+	if d.tokens[i].Color == nil {
+		return nil, fmt.Errorf("%w: %s has no color", errTokens, d.tokens[i].Path)
+	}
+	return d.tokens[i].Color, nil
+}
+
+// Set changes the color of the i-th token. Aliases are left untouched and
+// the token they reference is changed instead. The value is written in the
+// same form it was read in.
+func (d *Document) Set(i int, c colors.ColorSpace) error {
+	i, err := d.target(i)
+	if err != nil {
+		return err
+	}
+	t := &d.tokens[i]
+	text := t.value.edit(d.data[t.start:t.end], c)
+
+	delta := len(text) - (t.end - t.start)
+	d.data = append(d.data[:t.start:t.start], append([]byte(text), d.data[t.end:]...)...)
+	t.end += delta
+	t.Color = c
+	d.modified = true
+	for j := i + 1; j < len(d.tokens); j++ {
+		d.tokens[j].start += delta
+		d.tokens[j].end += delta
+	}
+	return nil
+}
+
+type found struct {
+	Token
+	node *node
+}
+
+type parser struct {
+	dec   *json.Decoder
+	data  []byte
+	found []found
+}
+
+// object reads the members of an object whose opening brace was consumed
+func (p *parser) object(path []string, n *node) error {
+	var tok *found
+	var desc string
+	for p.dec.More() {
+		k, err := p.dec.Token()
+		if err != nil {
+			return err
+		}
+		name := k.(string)
+
+		switch {
+		case name == "$value":
+			start, end, err := p.skip()
+			if err != nil {
+				return err
+			}
+			tok = &found{node: n, Token: Token{
+				Path:  strings.Join(path, "."),
+				Depth: len(path) - 1,
+				start: start,
+				end:   end,
+			}}
+		case name == "$type" || name == "$description":
+			start, end, err := p.skip()
+			if err != nil {
+				return err
+			}
+			var s string
+			if err := json.Unmarshal(p.data[start:end], &s); err != nil {
+				return fmt.Errorf("%s.%s: %w", strings.Join(path, "."), name, err)
+			}
+			if name == "$type" {
+				n.typ = s
+			} else {
+				desc = s
+			}
+		case strings.HasPrefix(name, "$"):
+			if _, _, err := p.skip(); err != nil {
+				return err
+			}
+		default:
+			start := p.offset()
+			if start >= len(p.data) || p.data[start] != '{' {
+				// Groups and tokens are objects, anything else isn't ours
+				if _, _, err := p.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			p.dec.Token() // Opening brace
+			if err := p.object(append(path, name), &node{parent: n}); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := p.dec.Token(); err != nil { // Closing brace
+		return err
+	}
+	if tok != nil && len(path) > 0 {
+		tok.Description = desc
+		p.found = append(p.found, *tok)
+	}
+	return nil
+}
+
+// offset is where the next value starts in the file
+func (p *parser) offset() int {
+	i := int(p.dec.InputOffset())
+	for i < len(p.data) && strings.IndexByte(" \t\r\n:,", p.data[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+// skip consumes the next value and returns where it is in the file
+func (p *parser) skip() (int, int, error) {
+	start := p.offset()
+	depth := 0
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return 0, 0, err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return start, int(p.dec.InputOffset()), nil
+		}
+	}
+}
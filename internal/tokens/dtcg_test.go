@@ -0,0 +1,148 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+const sample = `{
+  "$description": "Brand tokens",
+  "brand": {
+    "primary": {"$value": "#b7416e", "$description": "Buttons"},
+    "secondary": {
+      "$value": {"colorSpace": "oklch", "components": [0.5, 0.1, 200], "alpha": 1, "hex": "#00777f"}
+    },
+    "p3": {"$value": {"colorSpace": "display-p3", "components": [1, 0, 0], "hex": "#ff0000"}},
+    "$type": "color"
+  },
+  "spacing": {"small": {"$type": "dimension", "$value": {"value": 4, "unit": "px"}}},
+  "link": {"$type": "color", "$description": "Links", "$value": "{brand.primary}"},
+  "loop": {"$type": "color", "$value": "{loop}"}
+}
+`
+
+func TestParse(t *testing.T) {
+	d, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{}
+	for _, tok := range d.Tokens() {
+		paths = append(paths, tok.Path)
+	}
+	want := "brand.primary brand.secondary brand.p3 link loop"
+	if strings.Join(paths, " ") != want {
+		t.Fatalf("Expected the color tokens %q, got %q", want, paths)
+	}
+
+	tok := d.Tokens()
+	if tok[0].Description != "Buttons" || tok[3].Description != "Links" {
+		t.Errorf("Expected descriptions to be read, got %q and %q", tok[0].Description, tok[3].Description)
+	}
+	if tok[0].Group() != "brand" || tok[0].Name() != "primary" || tok[0].Depth != 1 {
+		t.Errorf("Expected brand.primary to be in the brand group, got %q %q %d", tok[0].Group(), tok[0].Name(), tok[0].Depth)
+	}
+	if o, ok := tok[1].Color.(colors.OKLCH); !ok || o.H != 200 {
+		t.Errorf("Expected the object form to be read as OKLCH, got %v", tok[1].Color)
+	}
+	if colors.Hex(tok[2].Color) != "#FF0000" {
+		t.Errorf("Expected unknown spaces to fall back to hex, got %v", tok[2].Color)
+	}
+	if c, err := d.Resolve(3); err != nil || colors.Hex(c) != "#B7416E" {
+		t.Errorf("Expected the alias to resolve to #B7416E, got %v (%v)", c, err)
+	}
+	if _, err := d.Resolve(4); err == nil {
+		t.Errorf("Expected an error resolving a circular alias")
+	}
+}
+
+func TestSet(t *testing.T) {
+	d, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Editing an alias edits the token it points to
+	if err := d.Set(3, colors.RGB{R: 1, G: 2, B: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set(1, colors.OKLCH{L: 0.6, C: 0.12, H: 120}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set(2, colors.RGB{R: 255, G: 255, B: 255}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.NewReplacer(
+		`"#b7416e"`, `"#010203"`,
+		`{"colorSpace": "oklch", "components": [0.5, 0.1, 200], "alpha": 1, "hex": "#00777f"}`,
+		`{"colorSpace": "oklch", "components": [0.6, 0.12, 120], "alpha": 1, "hex": "#778a2d"}`,
+		`{"colorSpace": "display-p3", "components": [1, 0, 0], "hex": "#ff0000"}`,
+		`{"colorSpace": "srgb", "components": [1, 1, 1], "hex": "#ffffff"}`,
+	).Replace(sample)
+	if got := string(d.Bytes()); got != want {
+		t.Errorf("Expected only the edited values to change:\n%s\ngot:\n%s", want, got)
+	}
+
+	// The edits can be read back
+	reread, err := Parse(d.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := reread.Resolve(3); colors.Hex(c) != "#010203" {
+		t.Errorf("Expected the edited alias target to be read back, got %v", c)
+	}
+}
+
+func TestSetKeepsLayout(t *testing.T) {
+	const multiline = `{
+  "accent": {
+    "$type": "color",
+    "$value": {
+      "colorSpace": "oklch",
+      "components": [
+        0.5,
+        0.1,
+        200
+      ],
+      "alpha": 1,
+      "hex": "#00777f"
+    }
+  }
+}
+`
+	d, err := Parse([]byte(multiline))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set(0, colors.OKLCH{L: 0.6, C: 0.12, H: 120}); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.NewReplacer("0.5,", "0.6,", "0.1,", "0.12,", "200\n", "120\n", "#00777f", "#778a2d").Replace(multiline)
+	if got := string(d.Bytes()); got != want {
+		t.Errorf("Expected the layout of the value to be kept:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	list := []swatches.Swatch{
+		{Color: colors.RGB{R: 255}, Name: "brand.primary", Notes: "Buttons"},
+		{Color: colors.RGB{B: 255}, Name: "brand.secondary"},
+		{Color: colors.RGB{}, Name: "black"},
+	}
+	d, err := Parse(Encode(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := d.Swatches()
+	if len(got) != len(list) {
+		t.Fatalf("Expected %d tokens, got %d:\n%s", len(list), len(got), Encode(list))
+	}
+	for i := range list {
+		if got[i].Name != list[i].Name || got[i].Notes != list[i].Notes || colors.Hex(got[i].Color) != colors.Hex(list[i].Color) {
+			t.Errorf("Expected %+v, got %+v", list[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package tokens
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/swatches"
+)
+
+// Swatches lists the color tokens as swatches named after their path, with
+// aliases resolved. Tokens without a readable color are left out.
+func (d *Document) Swatches() []swatches.Swatch {
+	list := []swatches.Swatch{}
+	for i, t := range d.tokens {
+		if c, err := d.Resolve(i); err == nil {
+			list = append(list, swatches.Swatch{Color: c, Name: t.Path, Notes: t.Description})
+		}
+	}
+	return list
+}
+
+// Encode writes swatches as a DTCG file. Dots in the names of the swatches
+// nest them in groups. Values use the object form with a hex fallback.
+func Encode(list []swatches.Swatch) []byte {
+	root := &group{}
+	for _, s := range list {
+		root.add(strings.Split(s.Name, "."), s)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("{\n")
+	fmt.Fprintf(&b, "  \"$type\": %q", colorType)
+	root.write(&b, 1)
+	b.WriteString("\n}\n")
+	return b.Bytes()
+}
+
+// group keeps the groups and tokens of an encoded file in insertion order
+type group struct {
+	names    []string
+	children map[string]*group
+	swatch   *swatches.Swatch
+}
+
+func (g *group) add(path []string, s swatches.Swatch) {
+	if len(path) == 0 {
+		g.swatch = &s
+		return
+	}
+	if g.children == nil {
+		g.children = map[string]*group{}
+	}
+	child, ok := g.children[path[0]]
+	if !ok {
+		child = &group{}
+		g.children[path[0]] = child
+		g.names = append(g.names, path[0])
+	}
+	child.add(path[1:], s)
+}
+
+// write writes the members of g, each one preceded by a comma since the
+// caller always writes a member first.
+func (g *group) write(b *bytes.Buffer, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if s := g.swatch; s != nil {
+		fmt.Fprintf(b, ",\n%s\"$value\": %s", indent, value{object: true, space: spaceSRGB, hex: true}.encode(s.Color))
+		if s.Notes != "" {
+			fmt.Fprintf(b, ",\n%s\"$description\": %s", indent, strconv.Quote(s.Notes))
+		}
+	}
+	for _, name := range g.names {
+		fmt.Fprintf(b, ",\n%s%s: {", indent, strconv.Quote(name))
+		var inner bytes.Buffer
+		g.children[name].write(&inner, depth+1)
+		b.Write(bytes.TrimPrefix(inner.Bytes(), []byte(",")))
+		fmt.Fprintf(b, "\n%s}", indent)
+	}
+}
+
+// Decode reads the color tokens of a DTCG file as swatches
+func Decode(data []byte) ([]swatches.Swatch, error) {
+	d, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return d.Swatches(), nil
+}
@@ -0,0 +1,43 @@
+package tokens
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+)
+
+type keybinds struct {
+	next, prev, nextGroup, prevGroup, put key.Binding
+}
+
+func newKeybinds() keybinds {
+	return keybinds{
+		next: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "next token"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "prev token"),
+		),
+		nextGroup: key.NewBinding(
+			key.WithKeys("J", "shift+down"),
+			key.WithHelp("J", "next group"),
+		),
+		prevGroup: key.NewBinding(
+			key.WithKeys("K", "shift+up"),
+			key.WithHelp("K", "prev group"),
+		),
+		put: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "set token to picked color"),
+		),
+	}
+}
+
+func Keys() []key.Binding {
+	k := newKeybinds()
+	return []key.Binding{k.next, k.prev, k.nextGroup, k.prevGroup, k.put}
+}
+
+func (m Model) AllKeys() [][]key.Binding {
+	return [][]key.Binding{Keys()}
+}
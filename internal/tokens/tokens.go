@@ -0,0 +1,175 @@
+package tokens
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/follow"
+	"github.com/ChausseBenjamin/termpicker/internal/ui"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	lg "github.com/charmbracelet/lipgloss/v2"
+)
+
+const maxRows = 8 // Rows of the token list shown at once
+
+// Model browses the color tokens of a document. Once a token is loaded in
+// the pickers, it follows the picked color until another one is loaded.
+// Edits are made to the document, which outlives the panel.
+type Model struct {
+	doc     *Document
+	sel     int
+	editing follow.Editing // Token following the picked color
+	picked  colors.ColorSpace
+}
+
+func New(doc *Document) *Model {
+	return &Model{doc: doc}
+}
+
+// Selected is the color of the selected token, with aliases resolved
+func (m Model) Selected() (colors.ColorSpace, bool) {
+	if m.doc.Len() == 0 {
+		return nil, false
+	}
+	c, err := m.doc.Resolve(m.sel)
+	return c, err == nil
+}
+
+// Load starts editing the selected token with the pickers
+func (m Model) Load() Model {
+	m.editing.Load(m.sel)
+	return m
+}
+
+// groupStart returns the first token of the run of tokens i belongs to
+func (m Model) groupStart(i int) int {
+	list := m.doc.Tokens()
+	for i > 0 && list[i-1].Group() == list[i].Group() {
+		i--
+	}
+	return i
+}
+
+// groupEnd returns the first token past the run of tokens i belongs to
+func (m Model) groupEnd(i int) int {
+	list := m.doc.Tokens()
+	for i < len(list)-1 && list[i+1].Group() == list[i].Group() {
+		i++
+	}
+	return i + 1
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keys := newKeybinds()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.doc.Len() == 0 {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.next):
+			m.sel = min(m.sel+1, m.doc.Len()-1)
+		case key.Matches(msg, keys.prev):
+			m.sel = max(m.sel-1, 0)
+		case key.Matches(msg, keys.nextGroup):
+			m.sel = min(m.groupEnd(m.sel), m.doc.Len()-1)
+		case key.Matches(msg, keys.prevGroup):
+			if start := m.groupStart(m.sel); start > 0 {
+				m.sel = m.groupStart(start - 1)
+			}
+		case key.Matches(msg, keys.put):
+			if m.picked != nil {
+				if err := m.doc.Set(m.sel, m.picked); err != nil {
+					return m, editErr(err)
+				}
+			}
+		}
+	case colors.ColorSpace:
+		m.picked = msg
+		if i, ok := m.editing.Pick(msg); ok {
+			if err := m.doc.Set(i, msg); err != nil {
+				// Following would fail the same way on every edit
+				m.editing.Stop()
+				return m, editErr(err)
+			}
+		}
+	}
+	return m, nil
+}
+
+// EditErrMsg reports a token the picked color couldn't be written to (ex:
+// an alias of a missing token)
+type EditErrMsg struct{ Err error }
+
+func editErr(err error) tea.Cmd {
+	return func() tea.Msg { return EditErrMsg{Err: err} }
+}
+
+func (m Model) View(width int) string {
+	count := fmt.Sprintf("%d color tokens", m.doc.Len())
+	if m.doc.Len() == 1 {
+		count = "1 color token"
+	}
+	title := fmt.Sprintf("Tokens %q (%s)", filepath.Base(m.doc.Path()), count)
+	if m.doc.Modified() {
+		title += " [modified]"
+	}
+	title = ui.Style().Readout.Render(title)
+	if m.doc.Len() == 0 {
+		return title
+	}
+
+	// Groups get a header whenever the run of tokens changes group
+	rows := []string{}
+	selRow := 0
+	for i, t := range m.doc.Tokens() {
+		if t.Group() != "" && (i == 0 || t.Group() != m.doc.Tokens()[i-1].Group()) {
+			indent := strings.Repeat("  ", t.Depth)
+			rows = append(rows, indent+ui.Style().Readout.Faint(true).Render(t.Group()))
+		}
+		if i == m.sel {
+			selRow = len(rows)
+		}
+		rows = append(rows, m.row(i))
+	}
+	start := max(0, min(selRow-maxRows/2, len(rows)-maxRows))
+	rows = rows[start:min(start+maxRows, len(rows))]
+
+	line := lg.NewStyle().MaxWidth(width)
+	for i, r := range rows {
+		rows[i] = line.Render(r)
+	}
+	return title + "\n" + strings.Join(rows, "\n")
+}
+
+// row renders the i-th token: its color, name and value
+func (m Model) row(i int) string {
+	t := m.doc.Tokens()[i]
+	cursor := "  "
+	name := ui.Style().Readout.Render(t.Name())
+	if i == m.sel {
+		cursor = ui.Style().PickerCursor.Render(ui.PickerSelRune) + " "
+		name = ui.Style().TabSel.Render(t.Name())
+	}
+
+	block, val := "??", "unreadable"
+	if c, err := m.doc.Resolve(i); err == nil {
+		block = lg.NewStyle().Background(lg.Color(colors.Hex(c))).Render("  ")
+		val = colors.Hex(c)
+	} else if t.Alias != "" {
+		val = err.Error()
+	}
+	if t.Alias != "" {
+		val = "{" + t.Alias + "} " + val
+	}
+	if m.editing.Is(i) {
+		val += " (editing)"
+	}
+	return cursor + strings.Repeat("  ", t.Depth) + block + " " + name + " " +
+		ui.Style().Readout.Faint(true).Render(val)
+}
@@ -0,0 +1,29 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func TestPutError(t *testing.T) {
+	d, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := *New(d)
+	m.sel = 4 // The circular alias
+	var model tea.Model = m
+	model, _ = model.Update(colors.ColorSpace(colors.RGB{R: 1, G: 2, B: 3}))
+	_, cmd := model.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	if cmd == nil {
+		t.Fatal("Expected an error to be reported")
+	}
+	if msg, ok := cmd().(EditErrMsg); !ok || msg.Err == nil {
+		t.Errorf("Expected an EditErrMsg, got %#v", cmd())
+	}
+	if d.Modified() {
+		t.Errorf("Expected the document to be left untouched")
+	}
+}
@@ -0,0 +1,284 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ChausseBenjamin/termpicker/internal/colors"
+	"github.com/ChausseBenjamin/termpicker/internal/parse"
+)
+
+// Color spaces of the object form which termpicker can write back. Values
+// in other spaces are read from their hex fallback and written back in sRGB.
+const (
+	spaceSRGB  = "srgb"
+	spaceHSL   = "hsl"
+	spaceLab   = "lab"
+	spaceOKLab = "oklab"
+	spaceOKLCH = "oklch"
+)
+
+// value remembers how a color was written so edits keep the same form:
+// either a string (ex: "#b7416e") or an object such as
+//
+//	{"colorSpace": "oklch", "components": [0.55, 0.15, 355], "hex": "#b7416e"}
+type value struct {
+	object bool
+	space  string
+	alpha  json.RawMessage // Kept as written, nil when missing
+	hex    bool            // Whether the object has a hex fallback
+	suffix string          // Alpha digits of 8 digit hex strings
+	lower  bool            // Whether hex codes are written in lowercase
+}
+
+type objectValue struct {
+	ColorSpace string          `json:"colorSpace"`
+	Components []any           `json:"components"`
+	Alpha      json.RawMessage `json:"alpha,omitempty"`
+	Hex        string          `json:"hex,omitempty"`
+}
+
+// decode reads a $value. Values termpicker can't read are kept in the file
+// but leave the token without a color.
+func (t *found) decode(raw []byte) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if alias, ok := strings.CutPrefix(s, "{"); ok && strings.HasSuffix(alias, "}") {
+			t.Alias = strings.TrimSuffix(alias, "}")
+			return
+		}
+		t.value.lower = s != strings.ToUpper(s)
+		if strings.HasPrefix(s, "#") && len(s) == 9 {
+			s, t.value.suffix = s[:7], s[7:]
+		}
+		t.Color, _ = parse.Color(s)
+		return
+	}
+
+	var obj objectValue
+	if json.Unmarshal(raw, &obj) != nil {
+		return
+	}
+	t.value = value{
+		object: true,
+		space:  obj.ColorSpace,
+		alpha:  obj.Alpha,
+		hex:    obj.Hex != "",
+		lower:  obj.Hex != strings.ToUpper(obj.Hex),
+	}
+	if c, ok := fromComponents(obj.ColorSpace, obj.Components); ok {
+		t.Color = c
+	} else if obj.Hex != "" {
+		t.Color, _ = parse.Color(obj.Hex)
+	}
+}
+
+// fromComponents reads the components of the spaces termpicker knows.
+// Missing components ("none") count as zero.
+func fromComponents(space string, components []any) (colors.ColorSpace, bool) {
+	if len(components) != 3 {
+		return nil, false
+	}
+	var v [3]float64
+	for i, c := range components {
+		switch c := c.(type) {
+		case float64:
+			v[i] = c
+		case string:
+			if c != "none" {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	switch space {
+	case spaceSRGB:
+		return colors.RGB{}.FromPrecise(colors.PreciseColor{R: v[0], G: v[1], B: v[2]}), true
+	case spaceHSL:
+		return colors.HSL{H: int(math.Round(v[0])), S: int(math.Round(v[1])), L: int(math.Round(v[2]))}, true
+	case spaceLab: // CSS Lab is relative to D50
+		return colors.Lab{}.FromPrecise(colors.LabD50ToPrecise(v[0], v[1], v[2])), true
+	case spaceOKLab:
+		return colors.OKLab{L: v[0], A: v[1], B: v[2]}, true
+	case spaceOKLCH:
+		return colors.OKLCH{L: v[0], C: v[1], H: v[2]}, true
+	default:
+		return nil, false
+	}
+}
+
+// encode writes c in the form the value was read in
+func (v value) encode(c colors.ColorSpace) string {
+	hex := v.hexCode(c)
+	if !v.object {
+		return strconv.Quote(hex + v.suffix)
+	}
+
+	space, nums := v.components(c)
+
+	// Written by hand to keep the members in the usual order and spacing
+	text := fmt.Sprintf(`{"colorSpace": %q, "components": [%s]`, space, strings.Join(nums, ", "))
+	if v.alpha != nil {
+		text += `, "alpha": ` + string(v.alpha)
+	}
+	if v.hex {
+		text += fmt.Sprintf(`, "hex": %q`, hex)
+	}
+	return text + "}"
+}
+
+// hexCode writes c as a hex code in the case it was read in
+func (v value) hexCode(c colors.ColorSpace) string {
+	if v.lower {
+		return strings.ToLower(colors.Hex(c))
+	}
+	return colors.Hex(c)
+}
+
+// components returns the space and components c is written with in the
+// object form
+func (v value) components(c colors.ColorSpace) (string, []string) {
+	space := v.space
+	var comp [3]float64
+	switch v.space {
+	case spaceHSL:
+		h := colors.HSL{}.FromPrecise(c.ToPrecise()).(colors.HSL)
+		comp = [3]float64{float64(h.H), float64(h.S), float64(h.L)}
+	case spaceLab:
+		comp[0], comp[1], comp[2] = colors.PreciseToLabD50(c.ToPrecise())
+	case spaceOKLab:
+		o, ok := c.(colors.OKLab)
+		if !ok {
+			o = colors.OKLab{}.FromPrecise(c.ToPrecise()).(colors.OKLab)
+		}
+		comp = [3]float64{o.L, o.A, o.B}
+	case spaceOKLCH:
+		o, ok := c.(colors.OKLCH)
+		if !ok {
+			o = colors.OKLCH{}.FromPrecise(c.ToPrecise()).(colors.OKLCH)
+		}
+		comp = [3]float64{o.L, o.C, o.H}
+	default:
+		p := c.ToPrecise()
+		space = spaceSRGB
+		comp = [3]float64{p.R, p.G, p.B}
+	}
+	nums := make([]string, len(comp))
+	for i, x := range comp {
+		nums[i] = strconv.FormatFloat(math.Round(x*1e5)/1e5, 'f', -1, 64)
+	}
+	return space, nums
+}
+
+// edit rewrites raw, the value as written in the file, to c. Only the color
+// space, components and hex fallback of the object form are replaced so the
+// layout of the object survives.
+func (v value) edit(raw []byte, c colors.ColorSpace) string {
+	if !v.object {
+		return v.encode(c)
+	}
+	spans, ok := objectSpans(raw)
+	if !ok {
+		return v.encode(c)
+	}
+	space, nums := v.components(c)
+	hex := v.hexCode(c)
+
+	edits := []span{spans.space.with(strconv.Quote(space))}
+	if spans.hex != nil {
+		edits = append(edits, spans.hex.with(strconv.Quote(hex)))
+	}
+	for i, n := range spans.components {
+		edits = append(edits, n.with(nums[i]))
+	}
+	// Spliced from the end so the earlier offsets stay valid
+	slices.SortFunc(edits, func(a, b span) int { return b.start - a.start })
+	text := string(raw)
+	for _, e := range edits {
+		text = text[:e.start] + e.text + text[e.end:]
+	}
+	return text
+}
+
+// span is where a JSON value is written, and what replaces it
+type span struct {
+	start, end int
+	text       string
+}
+
+func (s span) with(text string) span {
+	s.text = text
+	return s
+}
+
+// spans of the members of an object value which edits replace
+type spans struct {
+	space      span
+	hex        *span
+	components []span
+}
+
+// objectSpans finds the color space, hex fallback and each of the 3
+// numeric components in raw, an object value
+func objectSpans(raw []byte) (spans, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return spans{}, false
+	}
+	var found spans
+	hasSpace := false
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return spans{}, false
+		}
+		start := valueStart(raw, int(dec.InputOffset()))
+		switch key {
+		case "components":
+			if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+				return spans{}, false
+			}
+			for dec.More() {
+				elem := valueStart(raw, int(dec.InputOffset()))
+				if tok, err := dec.Token(); err != nil {
+					return spans{}, false
+				} else if _, isDelim := tok.(json.Delim); isDelim {
+					return spans{}, false
+				}
+				found.components = append(found.components, span{start: elem, end: int(dec.InputOffset())})
+			}
+			if _, err := dec.Token(); err != nil { // ]
+				return spans{}, false
+			}
+			continue
+		}
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return spans{}, false
+		}
+		s := span{start: start, end: int(dec.InputOffset())}
+		switch key {
+		case "colorSpace":
+			found.space, hasSpace = s, true
+		case "hex":
+			found.hex = &s
+		}
+	}
+	return found, hasSpace && len(found.components) == 3
+}
+
+// valueStart skips the separators (spaces, colons and commas) before the
+// value following offset
+func valueStart(raw []byte, offset int) int {
+	for offset < len(raw) && strings.ContainsRune(" \t\r\n:,", rune(raw[offset])) {
+		offset++
+	}
+	return offset
+}
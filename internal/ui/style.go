@@ -10,16 +10,28 @@ const (
 	textNorm  = "#A7AFB1"
 	textFaint = "#6F797B"
 	geomFg    = "#ACB3B5"
+	passFg    = "#7EC699"
+	failFg    = "#E06060"
 
 	TabSepLeft  = "["
 	TabSepMid   = " | "
 	TabSepRight = "]"
 
 	PickerSelRune = ">"
+	SwatchSelRune = "^"
 
 	PromptPrefix      = "> "
 	PromptPlaceholder = "Enter a color (ex: #b7416e)"
 
+	PromptNamePlaceholder  = "Enter a name"
+	PromptNotesPlaceholder = "Enter notes"
+	PromptOpenPlaceholder  = "Palette file to open (.json, .gpl, .ase, .txt or .tokens)"
+	PromptSavePlaceholder  = "Palette file to save to (.json, .gpl, .ase, .txt or .tokens)"
+	PromptThemePlaceholder = "Theme file to export to"
+	PromptLivePlaceholder  = "Terminal color to apply to (0-255, a color name, fg or bg)"
+
+	SliderEditPrompt = "="
+
 	SliderMinWidth = 22 // 1 ASCII change every 2.05 deg. avg
 	SliderMaxWidth = 90 // 2 ASCII change per deg.
 
@@ -40,6 +52,9 @@ type StyleSheet struct {
 	SliderLabel  lg.Style
 	PickerCursor lg.Style
 	Preview      lg.Style
+	Readout      lg.Style
+	Pass         lg.Style
+	Fail         lg.Style
 	InputPrompt  lg.Style
 	InputText    lg.Style
 	Notice       lg.Style
@@ -87,6 +102,17 @@ func init() {
 
 		Preview: baseStyle,
 
+		Readout: baseStyle,
+
+		Pass: baseStyle.Inherit(lg.NewStyle().
+			Foreground(lg.Color(passFg)).
+			Bold(true)),
+
+		Fail: baseStyle.Inherit(lg.NewStyle().
+			Foreground(lg.Color(failFg)).
+			Strikethrough(true).
+			Faint(true)),
+
 		InputPrompt: baseStyle.Inherit(lg.NewStyle().
 			Bold(true)),
 
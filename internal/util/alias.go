@@ -0,0 +1,31 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Aliases lists every accepted name of a set of options, each option being
+// given with all of its names (useful for help messages and shell
+// completion).
+func Aliases(options [][]string) []string {
+	list := []string{}
+	for _, names := range options {
+		list = append(list, names...)
+	}
+	return list
+}
+
+// LookupAlias returns the index of the option named s, ignoring case and
+// surrounding spaces. Unknown names are reported by wrapping err.
+func LookupAlias(options [][]string, s string, err error) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, names := range options {
+		for _, name := range names {
+			if s == name {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", err, s)
+}